@@ -0,0 +1,241 @@
+package figuring
+
+import (
+	"fmt"
+	"math"
+)
+
+// Arc represents a part of a circle. The arc starts at the \c start angle and
+// continues for \c sweep radians. Positive sweeps are counter-clockwise and
+// negative sweeps are clockwise.
+type Arc struct {
+	c     Pt
+	r     Length
+	start Radians
+	sweep Radians
+}
+
+// ArcCenter creates an arc around \c c with radius \c r, starting at the
+// angle \c start and sweeping \c sweep radians. Sweeps beyond a full circle
+// are limited to a full circle.
+func ArcCenter(c Pt, r Length, start, sweep Radians) Arc {
+	if r < 0 {
+		r = -r
+	}
+	sweep = Clamp(-2*math.Pi, sweep, 2*math.Pi)
+	return Arc{
+		c:     c,
+		r:     r,
+		start: start,
+		sweep: sweep,
+	}
+}
+
+// ArcFromPts creates the arc that starts at \c begin, passes through \c mid,
+// and ends at \c end. The arc is in error if the points are collinear.
+func ArcFromPts(begin, mid, end Pt) Arc {
+	circle := CircleFromPts(begin, mid, end)
+	if _, err := circle.OrErr(); err != nil {
+		return ArcCenter(circle.c, circle.r, Radians(math.NaN()), Radians(math.NaN()))
+	}
+	start := circle.c.VectorTo(begin).Angle()
+	stop := circle.c.VectorTo(end).Angle()
+	sweep := (stop - start).Normalize()
+	if orientPts(begin, mid, end) < 0 {
+		// Clockwise, so go the other way around.
+		sweep = -(start - stop).Normalize()
+	}
+	return ArcCenter(circle.c, circle.r, start, sweep)
+}
+
+// AppendFlatten appends the points of \c Flatten to \c dst, and returns the
+// extended slice. It doesn't allocate when \c dst has enough capacity.
+func (a Arc) AppendFlatten(dst []Pt, tolerance Length) []Pt {
+	if _, err := a.OrErr(); err != nil || !(tolerance > 0) {
+		return dst
+	}
+	steps := arcFlattenSteps(a.r, a.sweep, tolerance)
+	dst = append(dst, a.Begin())
+	for h := 1; h < steps; h++ {
+		dst = append(dst, a.PtAtT(float64(h)/float64(steps)))
+	}
+	return append(dst, a.End())
+}
+
+// Begin returns the first point of the arc.
+func (a Arc) Begin() Pt { return a.PtAtT(0) }
+
+// BoundingBox returns an axis-aligned rectangle that encompasses all the
+// points of the arc.
+func (a Arc) BoundingBox() Rectangle {
+	pts := []Pt{a.Begin(), a.End()}
+	for h := 0; h < 4; h++ {
+		theta := Radians(float64(h) * math.Pi / 2)
+		if _, ok := a.tAtTheta(theta); ok {
+			pts = append(pts, a.Circle().PtAtTheta(theta))
+		}
+	}
+	lx, mx, ly, my := LimitsPts(pts)
+	return RectanglePt(PtXy(lx, ly), PtXy(mx, my))
+}
+
+// Center returns the center of the circle the arc is part of.
+func (a Arc) Center() Pt { return a.c }
+
+// Circle returns the circle the arc is part of.
+func (a Arc) Circle() Circle { return CirclePt(a.c, a.r) }
+
+// ClosestPt returns the value of \c t, the point, and the distance of the
+// point of the arc closest to \c p. See \c PtAtT.
+func (a Arc) ClosestPt(p Pt) (float64, Pt, Length) {
+	if t, ok := a.TAtPt(p); ok {
+		d := a.c.VectorTo(p).Magnitude() - a.r
+		if d < 0 {
+			d = -d
+		}
+		return t, a.PtAtT(t), d
+	}
+	begin, end := a.Begin(), a.End()
+	if db, de := p.VectorTo(begin).Magnitude(), p.VectorTo(end).Magnitude(); de < db {
+		return 1, end, de
+	} else {
+		return 0, begin, db
+	}
+}
+
+// End returns the last point of the arc.
+func (a Arc) End() Pt { return a.PtAtT(1) }
+
+// Flatten returns the points of a polyline that is no more than
+// \c tolerance from any point of the arc. The chords are equal. See
+// \c SegmentsFromPts. The tolerance must be greater than zero.
+func (a Arc) Flatten(tolerance Length) []Pt { return a.AppendFlatten(nil, tolerance) }
+
+// Length returns the distance along the arc.
+func (a Arc) Length() Length {
+	sweep := a.sweep
+	if sweep < 0 {
+		sweep = -sweep
+	}
+	return a.r * Length(sweep)
+}
+
+// OrErr returns a floating point error if the center, radius, or angles are
+// in error.
+func (a Arc) OrErr() (Arc, *FloatingPointError) {
+	if _, err := a.Circle().OrErr(); err != nil {
+		return a, err
+	} else if _, err := a.start.OrErr(); err != nil {
+		return a, err
+	} else if _, err := a.sweep.OrErr(); err != nil {
+		return a, err
+	}
+	return a, nil
+}
+
+// PtAtT returns the point for the provided value of \c t. The arc is
+// parameterized by arc length, with \c t from 0 at \c Begin to 1 at \c End.
+func (a Arc) PtAtT(t float64) Pt {
+	t = Clamp(0, t, 1)
+	return a.Circle().PtAtTheta(a.start + a.sweep*Radians(t))
+}
+
+// Radius returns the radius of the arc.
+func (a Arc) Radius() Length { return a.r }
+
+// Reverse returns the same arc, going from \c End to \c Begin.
+func (a Arc) Reverse() Arc { return ArcCenter(a.c, a.r, a.start+a.sweep, -a.sweep) }
+
+// SplitAtLength splits the arc into two arcs, with the first arc \c length
+// long and the second arc the remainder.
+func (a Arc) SplitAtLength(length Length) (Arc, Arc) {
+	total := a.Length()
+	if IsZero(total) {
+		return a.SplitAtT(0)
+	}
+	return a.SplitAtT(float64(length / total))
+}
+
+// SplitAtT splits the arc into two arcs at \c t.
+func (a Arc) SplitAtT(t float64) (Arc, Arc) {
+	t = Clamp(0, t, 1)
+	first := a.sweep * Radians(t)
+	return ArcCenter(a.c, a.r, a.start, first),
+		ArcCenter(a.c, a.r, a.start+first, a.sweep-first)
+}
+
+// Start returns the angle of \c Begin from the center.
+func (a Arc) Start() Radians { return a.start }
+
+// String returns a string representation of the arc.
+func (a Arc) String() string {
+	return fmt.Sprintf("Arc(%v, %s, %v, %v)",
+		a.c,
+		HumanFormat(9, a.r),
+		a.start,
+		a.sweep,
+	)
+}
+
+// Sweep returns the angle covered by the arc. Positive values are
+// counter-clockwise and negative values are clockwise.
+func (a Arc) Sweep() Radians { return a.sweep }
+
+// TangentAtT returns the tangent and the normal of the arc for the given
+// value of \c t.
+func (a Arc) TangentAtT(t float64) (Vector, Vector) {
+	t = Clamp(0, t, 1)
+	theta := a.start + a.sweep*Radians(t)
+	tangent := VectorFromTheta(theta + math.Pi/2).Scale(a.r * Length(a.sweep))
+	ti, tj := tangent.Units()
+	normal := VectorIj(-tj, ti)
+	return tangent, normal
+}
+
+// TAtPt returns the value of \c t for the point of the arc closest to \c p
+// in angle. Returns false if the angle of \c p from the center is not covered
+// by the arc.
+func (a Arc) TAtPt(p Pt) (float64, bool) {
+	return a.tAtTheta(a.c.VectorTo(p).Angle())
+}
+
+// tAtTheta returns the value of \c t where the arc reaches the angle
+// \c theta. Angles within tolerance of the ends are treated as the ends.
+func (a Arc) tAtTheta(theta Radians) (float64, bool) {
+	return sweepT(a.start, a.sweep, theta)
+}
+
+// sweepT returns how far \c theta is through the angles swept from \c start,
+// with 0 at \c start and 1 at \c start + \c sweep. Returns false if
+// \c theta is not swept.
+func sweepT(start, sweep, theta Radians) (float64, bool) {
+	if IsZero(sweep) {
+		if IsZero((theta - start).Normalize()) {
+			return 0, true
+		}
+		return 0, false
+	}
+	delta := (theta - start).Normalize()
+	if sweep < 0 {
+		delta = (start - theta).Normalize()
+	}
+	if IsZero(delta) {
+		return 0, true
+	}
+	t := float64(delta) / math.Abs(float64(sweep))
+	if t <= 1 || IsEqual(t, 1) {
+		return math.Min(t, 1), true
+	}
+	return 0, false
+}
+
+// FilterPtsArc returns the points of \c pts that are at an angle covered by
+// the arc. The distance from the center is not checked.
+func FilterPtsArc(a Arc, pts []Pt) (ret []Pt) {
+	for _, p := range pts {
+		if _, ok := a.TAtPt(p); ok {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,278 @@
+package figuring
+
+import (
+	"math"
+	"testing"
+)
+
+func TestArc(t *testing.T) {
+	quarter := ArcCenter(PtXy(0, 0), 2, 0, math.Pi/2)
+	clockwise := ArcCenter(PtXy(1, 1), 1, math.Pi/2, -math.Pi)
+	wide := ArcCenter(PtXy(0, 0), 1, math.Pi/4, 3*math.Pi/2)
+
+	identityTests := []struct {
+		a          Arc
+		begin, end Pt
+		length     Length
+		box        Rectangle
+	}{
+		{
+			//0
+			quarter, PtXy(2, 0), PtXy(0, 2), Length(math.Pi),
+			RectanglePt(PtXy(0, 0), PtXy(2, 2)),
+		}, {
+			clockwise, PtXy(1, 2), PtXy(1, 0), Length(math.Pi),
+			RectanglePt(PtXy(1, 0), PtXy(2, 2)),
+		}, {
+			wide, PtXy(0.707106781187, 0.707106781187), PtXy(0.707106781187, -0.707106781187), 3 * Length(math.Pi) / 2,
+			RectanglePt(PtXy(-1, -1), PtXy(0.707106781187, 1)),
+		}, {
+			ArcFromPts(PtXy(1, 0), PtXy(0, 1), PtXy(-1, 0)), PtXy(1, 0), PtXy(-1, 0), Length(math.Pi),
+			RectanglePt(PtXy(-1, 0), PtXy(1, 1)),
+		}, {
+			ArcFromPts(PtXy(1, 0), PtXy(0, -1), PtXy(-1, 0)), PtXy(1, 0), PtXy(-1, 0), Length(math.Pi),
+			RectanglePt(PtXy(-1, -1), PtXy(1, 0)),
+		}, {
+			//5
+			ArcCenter(PtXy(0, 0), 1, 0, 3*math.Pi), PtXy(1, 0), PtXy(1, 0), 2 * Length(math.Pi),
+			RectanglePt(PtXy(-1, -1), PtXy(1, 1)),
+		},
+	}
+	for h, test := range identityTests {
+		a := test.a
+		if begin := a.Begin(); !IsEqualPair(begin, test.begin) {
+			t.Errorf("[%d](%v).Begin() failed. %v != %v",
+				h, a, begin, test.begin)
+		}
+		if end := a.End(); !IsEqualPair(end, test.end) {
+			t.Errorf("[%d](%v).End() failed. %v != %v",
+				h, a, end, test.end)
+		}
+		if length := a.Length(); !IsEqual(length, test.length) {
+			t.Errorf("[%d](%v).Length() failed. %f != %f",
+				h, a, length, test.length)
+		}
+		if box := a.BoundingBox(); !IsEqualPair(box.MinPt(), test.box.MinPt()) || !IsEqualPair(box.MaxPt(), test.box.MaxPt()) {
+			t.Errorf("[%d](%v).BoundingBox() failed. %v != %v",
+				h, a, box, test.box)
+		}
+	}
+
+	if a := ArcFromPts(PtXy(0, 0), PtXy(1.5, 5e-7), PtXy(3, 0)); !IsEqualPair(a.End(), PtXy(3, 0)) || a.Sweep() > 0 || a.Sweep() < -1e-5 {
+		t.Errorf("ArcFromPts() with a shallow clockwise arc failed. %v", a)
+	}
+	if _, err := ArcFromPts(PtXy(0, 0), PtXy(1, 1), PtXy(2, 2)).OrErr(); err == nil {
+		t.Errorf("ArcFromPts() with collinear points failed. should be in error")
+	}
+
+	tTests := []struct {
+		a       Arc
+		t       float64
+		pt      Pt
+		tangent Vector
+	}{
+		{
+			//0
+			quarter, 0.5, PtXy(1.414213562373, 1.414213562373), VectorIj(-2.221441469079, 2.221441469079),
+		}, {
+			clockwise, 0.5, PtXy(2, 1), VectorIj(0, -math.Pi),
+		}, {
+			clockwise, 2, PtXy(1, 0), VectorIj(-math.Pi, 0),
+		},
+	}
+	for h, test := range tTests {
+		a := test.a
+		if p := a.PtAtT(test.t); !IsEqualPair(p, test.pt) {
+			t.Errorf("[%d](%v).PtAtT(%f) failed. %v != %v",
+				h, a, test.t, p, test.pt)
+		}
+		if tangent, _ := a.TangentAtT(test.t); !IsEqualPair(tangent, test.tangent) {
+			t.Errorf("[%d](%v).TangentAtT(%f) failed. %v != %v",
+				h, a, test.t, tangent, test.tangent)
+		}
+	}
+
+	splitTests := []struct {
+		a      Arc
+		length Length
+		mid    Pt
+	}{
+		{
+			//0
+			quarter, Length(math.Pi) / 2, PtXy(1.414213562373, 1.414213562373),
+		}, {
+			clockwise, Length(math.Pi) / 2, PtXy(2, 1),
+		}, {
+			clockwise, 0, PtXy(1, 2),
+		}, {
+			clockwise, 10, PtXy(1, 0),
+		},
+	}
+	for h, test := range splitTests {
+		a := test.a
+		first, second := a.SplitAtLength(test.length)
+		if !IsEqualPair(first.End(), test.mid) || !IsEqualPair(second.Begin(), test.mid) {
+			t.Errorf("[%d](%v).SplitAtLength(%f) failed. %v, %v != %v",
+				h, a, test.length, first.End(), second.Begin(), test.mid)
+		}
+		if !IsEqualPair(first.Begin(), a.Begin()) || !IsEqualPair(second.End(), a.End()) {
+			t.Errorf("[%d](%v).SplitAtLength(%f) (ends) failed. %v, %v",
+				h, a, test.length, first, second)
+		}
+		if sum := first.Length() + second.Length(); !IsEqual(sum, a.Length()) {
+			t.Errorf("[%d](%v).SplitAtLength(%f) (length) failed. %f != %f",
+				h, a, test.length, sum, a.Length())
+		}
+	}
+
+	if r := clockwise.Reverse(); !IsEqualPair(r.Begin(), clockwise.End()) || !IsEqualPair(r.End(), clockwise.Begin()) || !IsEqualPair(r.PtAtT(0.5), PtXy(2, 1)) {
+		t.Errorf("(%v).Reverse() failed. %v", clockwise, r)
+	}
+
+	closestTests := []struct {
+		a    Arc
+		p    Pt
+		t    float64
+		pt   Pt
+		dist Length
+	}{
+		{
+			//0
+			quarter, PtXy(3, 3),
+			0.5, PtXy(1.414213562373, 1.414213562373), 2.242640687119,
+		}, {
+			quarter, PtXy(-1, -0.1),
+			1, PtXy(0, 2), 2.325941100808,
+		}, {
+			clockwise, PtXy(3, 1),
+			0.5, PtXy(2, 1), 1,
+		},
+	}
+	for h, test := range closestTests {
+		tv, pt, dist := test.a.ClosestPt(test.p)
+		if !IsEqual(tv, test.t) || !IsEqualPair(pt, test.pt) || !IsEqual(dist, test.dist) {
+			t.Errorf("[%d](%v).ClosestPt(%v) failed. %f, %v, %f != %f, %v, %f",
+				h, test.a, test.p, tv, pt, dist, test.t, test.pt, test.dist)
+		}
+	}
+}
+
+func TestIntersectionArc(t *testing.T) {
+	upper := ArcCenter(PtXy(0, 0), 1, 0, math.Pi)
+	checkPts := func(name string, h int, a, b interface{}, pts, expected []Pt) {
+		if len(pts) != len(expected) {
+			t.Errorf("[%d]%s(%v, %v) (length) failed. %v != %v",
+				h, name, a, b, pts, expected)
+			return
+		}
+		for i := 0; i < len(pts); i++ {
+			if !IsEqualPair(pts[i], expected[i]) {
+				t.Errorf("[%d][%d]%s(%v, %v) failed. %v != %v",
+					h, i, name, a, b, pts[i], expected[i])
+			}
+		}
+	}
+
+	arcLineTests := []struct {
+		a   Arc
+		b   Line
+		pts []Pt
+	}{
+		{
+			//0
+			upper, LineXAxis,
+			[]Pt{PtXy(-1, 0), PtXy(1, 0)},
+		}, {
+			upper, LineYAxis,
+			[]Pt{PtXy(0, 1)},
+		}, {
+			upper, LineFromPt(PtXy(-2, -0.5), PtXy(2, -0.5)),
+			nil,
+		},
+	}
+	for h, test := range arcLineTests {
+		checkPts("IntersectionArcLine", h, test.a, test.b,
+			IntersectionArcLine(test.a, test.b), test.pts)
+	}
+
+	arcSegmentTests := []struct {
+		a   Arc
+		b   Segment
+		pts []Pt
+	}{
+		{
+			//0
+			upper, SegmentPt(PtXy(0, -2), PtXy(0, 2)),
+			[]Pt{PtXy(0, 1)},
+		}, {
+			upper, SegmentPt(PtXy(0, -2), PtXy(0, 0.5)),
+			nil,
+		},
+	}
+	for h, test := range arcSegmentTests {
+		checkPts("IntersectionArcSegment", h, test.a, test.b,
+			IntersectionArcSegment(test.a, test.b), test.pts)
+	}
+
+	arcCircleTests := []struct {
+		a   Arc
+		b   Circle
+		pts []Pt
+	}{
+		{
+			//0
+			upper, CirclePt(PtXy(1, 0), 1),
+			[]Pt{PtXy(0.5, 0.866025403784)},
+		}, {
+			ArcCenter(PtXy(0, 0), 1, math.Pi, math.Pi), CirclePt(PtXy(1, 0), 1),
+			[]Pt{PtXy(0.5, -0.866025403784)},
+		}, {
+			upper, CirclePt(PtXy(0, 0), 1),
+			nil,
+		},
+	}
+	for h, test := range arcCircleTests {
+		checkPts("IntersectionArcCircle", h, test.a, test.b,
+			IntersectionArcCircle(test.a, test.b), test.pts)
+	}
+
+	arcArcTests := []struct {
+		a, b Arc
+		pts  []Pt
+	}{
+		{
+			//0
+			upper, ArcCenter(PtXy(1, 0), 1, math.Pi/2, math.Pi),
+			[]Pt{PtXy(0.5, 0.866025403784)},
+		}, {
+			upper, ArcCenter(PtXy(1, 0), 1, -math.Pi/2, math.Pi),
+			nil,
+		},
+	}
+	for h, test := range arcArcTests {
+		checkPts("IntersectionArcArc", h, test.a, test.b,
+			IntersectionArcArc(test.a, test.b), test.pts)
+	}
+
+	arcBezierTests := []struct {
+		a   Arc
+		b   Bezier
+		pts []Pt
+	}{
+		{
+			//0
+			upper, BezierPt(PtXy(-2, 0), PtXy(-1, 0), PtXy(1, 0), PtXy(2, 0)),
+			[]Pt{PtXy(-1, 0), PtXy(1, 0)},
+		}, {
+			ArcCenter(PtXy(0, 0), 1, 0, math.Pi/2), BezierPt(PtXy(-2, 0), PtXy(-1, 0), PtXy(1, 0), PtXy(2, 0)),
+			[]Pt{PtXy(1, 0)},
+		}, {
+			upper, BezierPt(PtXy(-2, -0.5), PtXy(-1, -0.5), PtXy(1, -0.5), PtXy(2, -0.5)),
+			nil,
+		},
+	}
+	for h, test := range arcBezierTests {
+		checkPts("IntersectionArcBezier", h, test.a, test.b,
+			IntersectionArcBezier(test.a, test.b), test.pts)
+	}
+}
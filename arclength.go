@@ -0,0 +1,213 @@
+package figuring
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// arcLengthTableEntries is the number of entries in the tables built for
+	// lookups of many lengths, such as \c Bezier.EquidistantPts.
+	arcLengthTableEntries = 32
+	// arcLengthMaxIterations limits the refinement of a value of t within an
+	// entry of the table.
+	arcLengthMaxIterations = 32
+)
+
+// ArcLengthTable maps between the value of \c t of a curve and the distance
+// along the curve from its beginning. The table divides the curve into equal
+// steps of \c t, and stores the length at each step. Lookups only integrate
+// the curve within a single step, so repeated lookups are much faster than
+// measuring the curve each time.
+//
+// Create a table with \c Bezier.ArcLengthTable, \c ParamCurve.ArcLengthTable,
+// or \c QuadraticBezier.ArcLengthTable.
+type ArcLengthTable struct {
+	ts      []float64
+	lengths []Length
+	ptAtT   func(float64) Pt
+	speed   func(float64) float64
+}
+
+// arcLengthTablePolynomial creates the table for the curve with the functions
+// \c x and \c y between \c lo and \c hi.
+func arcLengthTablePolynomial(x, y Derivable, lo, hi float64, entries int, ptAtT func(float64) Pt) ArcLengthTable {
+	speed := arcLengthSpeed(x, y)
+	if entries < 1 {
+		entries = 1
+	}
+	table := ArcLengthTable{
+		ts:      make([]float64, entries+1),
+		lengths: make([]Length, entries+1),
+		ptAtT:   ptAtT,
+		speed:   speed,
+	}
+	table.ts[0] = lo
+	for h := 1; h <= entries; h++ {
+		t := lo + (hi-lo)*float64(h)/float64(entries)
+		table.ts[h] = t
+		table.lengths[h] = table.lengths[h-1] + Length(legendreGaussIntegrate(speed, table.ts[h-1], t))
+	}
+	return table
+}
+
+// EquidistantPts returns \c n points along the curve, with the same distance
+// along the curve between each point and the next. The first point is the
+// beginning of the curve, and the last point is the end. Returns nil if \c n
+// is less than 1, and the beginning if \c n is 1.
+func (table ArcLengthTable) EquidistantPts(n int) []Pt {
+	if n < 1 {
+		return nil
+	} else if n == 1 {
+		return []Pt{table.ptAtT(table.ts[0])}
+	}
+	total := table.Length()
+	pts := make([]Pt, n)
+	for h := range pts {
+		pts[h] = table.PtAtLength(total * Length(h) / Length(n-1))
+	}
+	// Use the exact end rather than the searched one.
+	pts[n-1] = table.ptAtT(table.ts[len(table.ts)-1])
+	return pts
+}
+
+// Length returns the length of the whole curve.
+func (table ArcLengthTable) Length() Length { return table.lengths[len(table.lengths)-1] }
+
+// LengthAtT returns the distance along the curve from its beginning to \c t.
+// Values of \c t outside the curve are clamped to the curve.
+func (table ArcLengthTable) LengthAtT(t float64) Length {
+	lo, hi := table.ts[0], table.ts[len(table.ts)-1]
+	t = Clamp(lo, t, hi)
+	// The entry with the largest t that is not past \c t.
+	h := sort.SearchFloat64s(table.ts, t)
+	if h == len(table.ts) || table.ts[h] > t {
+		h--
+	}
+	if table.ts[h] == t {
+		return table.lengths[h]
+	}
+	return table.lengths[h] + Length(legendreGaussIntegrate(table.speed, table.ts[h], t))
+}
+
+// PtAtLength returns the point \c length along the curve from its
+// beginning. See \c TAtLength.
+func (table ArcLengthTable) PtAtLength(length Length) Pt {
+	return table.ptAtT(table.TAtLength(length))
+}
+
+// TAtLength returns the value of \c t that is \c length along the curve from
+// its beginning. Lengths outside the curve are clamped to the curve.
+func (table ArcLengthTable) TAtLength(length Length) float64 {
+	last := len(table.lengths) - 1
+	if length <= 0 || math.IsNaN(float64(length)) {
+		return table.ts[0]
+	} else if length >= table.lengths[last] {
+		return table.ts[last]
+	}
+	// The entry that contains the length.
+	h := sort.Search(last, func(i int) bool { return table.lengths[i+1] >= length })
+	a, b := table.ts[h], table.ts[h+1]
+	la, lb := table.lengths[h], table.lengths[h+1]
+	if IsEqual(la, lb) {
+		return a
+	}
+	t := a + (b-a)*float64((length-la)/(lb-la))
+	return arcLengthNewton(table.speed, func(t float64) Length {
+		return la + Length(legendreGaussIntegrate(table.speed, table.ts[h], t))
+	}, a, b, t, length)
+}
+
+// arcLengthSpeed returns the speed of the curve with the functions \c x and
+// \c y, which is the length of the derivative.
+func arcLengthSpeed(x, y Derivable) func(float64) float64 {
+	dx, dy := x.Derivative(), y.Derivative()
+	return func(t float64) float64 {
+		i, j := dx.AtT(t), dy.AtT(t)
+		return math.Sqrt(i*i + j*j)
+	}
+}
+
+// arcLengthIntegral returns a function that measures the curve with the
+// functions \c x and \c y from \c lo to \c t. The integral is split where
+// the speed has a minimum or a maximum, because the speed is not smooth near
+// a cusp, such as where a curve turns back on itself.
+func arcLengthIntegral(x, y Derivable, lo, hi float64) func(float64) Length {
+	speed := arcLengthSpeed(x, y)
+	breaks := []float64{lo}
+	xd, xok := x.Derivative().(Coefficienter)
+	yd, yok := y.Derivative().(Coefficienter)
+	if xok && yok {
+		// The speed squared is dx^2+dy^2, so its extremes are the roots of
+		// dx*ddx + dy*ddy.
+		dx, dy := PolynomialNFrom(xd), PolynomialNFrom(yd)
+		extremes := dx.Mul(dx.FirstDerivative()).Add(dy.Mul(dy.FirstDerivative()))
+		for _, root := range extremes.RealRoots(lo, hi) {
+			if lo < root.T && root.T < hi {
+				breaks = append(breaks, root.T)
+			}
+		}
+	}
+	return func(t float64) Length {
+		var sum float64
+		for h, b := range breaks {
+			if b >= t {
+				break
+			}
+			end := t
+			if h+1 < len(breaks) && breaks[h+1] < t {
+				end = breaks[h+1]
+			}
+			sum += legendreGaussIntegrate(speed, b, end)
+		}
+		return Length(sum)
+	}
+}
+
+// lengthAtTPolynomial returns the distance along the curve with the
+// functions \c x and \c y from \c lo to \c t, integrated directly. Values of
+// \c t outside the curve are clamped to the curve.
+func lengthAtTPolynomial(x, y Derivable, lo, hi, t float64) Length {
+	return arcLengthIntegral(x, y, lo, hi)(Clamp(lo, t, hi))
+}
+
+// tAtLengthPolynomial returns the value of \c t that is \c length along the
+// curve with the functions \c x and \c y from \c lo, using the integral from
+// \c lo directly instead of a table. Lengths outside the curve are clamped
+// to the curve.
+func tAtLengthPolynomial(x, y Derivable, lo, hi float64, length Length) float64 {
+	lengthAtT := arcLengthIntegral(x, y, lo, hi)
+	total := lengthAtT(hi)
+	if length <= 0 || math.IsNaN(float64(length)) {
+		return lo
+	} else if length >= total {
+		return hi
+	}
+	t := lo + (hi-lo)*float64(length/total)
+	return arcLengthNewton(arcLengthSpeed(x, y), lengthAtT, lo, hi, t, length)
+}
+
+// arcLengthNewton returns the value of \c t between \c a and \c b where
+// \c lengthAtT is \c length, starting from \c t.
+func arcLengthNewton(speed func(float64) float64, lengthAtT func(float64) Length, a, b, t float64, length Length) float64 {
+	// Newton's method, falling back to bisection when a step leaves the
+	// range or the curve stops.
+	// see https://en.wikipedia.org/wiki/Newton%27s_method
+	for i := 0; i < arcLengthMaxIterations; i++ {
+		diff := lengthAtT(t) - length
+		if IsZero(diff) {
+			break
+		}
+		if diff > 0 {
+			b = t
+		} else {
+			a = t
+		}
+		next := t - float64(diff)/speed(t)
+		if !(a < next && next < b) {
+			next = a + (b-a)/2
+		}
+		t = next
+	}
+	return t
+}
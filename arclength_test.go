@@ -0,0 +1,185 @@
+package figuring
+
+import (
+	"testing"
+)
+
+func TestArcLengthTable(t *testing.T) {
+	type arcLengther interface {
+		ArcLengthTable(int) ArcLengthTable
+		EquidistantPts(int) []Pt
+		Length() Length
+		LengthAtT(float64) Length
+		PtAtLength(Length) Pt
+		PtAtT(float64) Pt
+		TAtLength(Length) float64
+	}
+	// A straight line that slows down, so t and length are not proportional.
+	line := BezierPt(PtXy(0, 0), PtXy(9, 0), PtXy(10, 0), PtXy(10, 0))
+	// Goes out to (2, 0) and comes back, stopping at the turn.
+	doubled := QuadraticBezierPt(PtXy(0, 0), PtXy(4, 0), PtXy(0, 0))
+	cubic := BezierPt(PtXy(10, 10), PtXy(10, 40), PtXy(50, 45), PtXy(45, -10))
+	param := ParamCubic(PtXy(10, 10), PtXy(10, 40), PtXy(50, 45), PtXy(45, -10))
+
+	lookupTests := []struct {
+		a      arcLengther
+		length Length
+		pt     Pt
+	}{
+		{
+			//0
+			line, 5,
+			PtXy(5, 0),
+		}, {
+			line, 0,
+			PtXy(0, 0),
+		}, {
+			line, 12,
+			PtXy(10, 0),
+		}, {
+			line, -1,
+			PtXy(0, 0),
+		}, {
+			doubled, 1,
+			PtXy(1, 0),
+		}, {
+			//5
+			doubled, 3,
+			PtXy(1, 0),
+		}, {
+			cubic, 40,
+			PtXy(35.306091823, 29.089356586),
+		}, {
+			param, 40,
+			PtXy(35.306091823, 29.089356586),
+		},
+	}
+	for h, test := range lookupTests {
+		a, length := test.a, test.length
+		if p := a.PtAtLength(length); !IsEqualPair(p, test.pt) {
+			t.Errorf("[%d](%v).PtAtLength(%f) failed. %v != %v",
+				h, a, length, p, test.pt)
+		}
+		tv := a.TAtLength(length)
+		if p := a.PtAtT(tv); !IsEqualPair(p, test.pt) {
+			t.Errorf("[%d](%v).TAtLength(%f) failed. %v != %v",
+				h, a, length, p, test.pt)
+		}
+		expected := Clamp(0, length, a.Length())
+		if l := a.LengthAtT(tv); !IsEqual(l, expected) {
+			t.Errorf("[%d](%v).LengthAtT(%f) failed. %f != %f",
+				h, a, tv, l, expected)
+		}
+	}
+
+	tableTests := []struct {
+		a       arcLengther
+		entries int
+	}{
+		{line, 1},
+		{line, 16},
+		{doubled, 32},
+		{cubic, 0},
+		{cubic, 8},
+		{cubic, 100},
+		{param, 8},
+	}
+	for h, test := range tableTests {
+		a := test.a
+		table := a.ArcLengthTable(test.entries)
+		if l := table.Length(); !IsEqual(l, a.Length()) {
+			t.Errorf("[%d](%v).ArcLengthTable(%d).Length() failed. %f != %f",
+				h, a, test.entries, l, a.Length())
+		}
+		for i := 0; i <= 10; i++ {
+			length := table.Length() * Length(i) / 10
+			tv := table.TAtLength(length)
+			if l := table.LengthAtT(tv); !IsEqual(l, length) {
+				t.Errorf("[%d][%d](%v).ArcLengthTable(%d).LengthAtT(%f) failed. %f != %f",
+					h, i, a, test.entries, tv, l, length)
+			}
+		}
+	}
+
+	// Direct lookups match a table, including across the turn of doubled.
+	for h, a := range []arcLengther{cubic, doubled, param} {
+		table := a.ArcLengthTable(64)
+		for i := 0; i <= 10; i++ {
+			tv := float64(i) / 10
+			if pl, ok := a.(ParamCurve); ok {
+				tv = pl.Min + (pl.Max-pl.Min)*tv
+			}
+			if l, e := a.LengthAtT(tv), table.LengthAtT(tv); !IsEqual(l, e) {
+				t.Errorf("[%d][%d](%v).LengthAtT(%f) (table) failed. %f != %f", h, i, a, tv, l, e)
+			}
+			length := table.Length() * Length(i) / 10
+			if l, e := a.TAtLength(length), table.TAtLength(length); !IsEqual(l, e) {
+				t.Errorf("[%d][%d](%v).TAtLength(%f) (table) failed. %f != %f", h, i, a, length, l, e)
+			}
+		}
+	}
+
+	// The first part of a split is the requested length.
+	for h, length := range []Length{0, 10, 40, 75} {
+		first, second := cubic.SplitAtLength(length)
+		if l := first.Length(); !IsEqual(l, length) {
+			t.Errorf("[%d](%v).SplitAtLength(%f) failed. %f != %f", h, cubic, length, l, length)
+		}
+		if !IsEqualPair(first.End(), second.Begin()) {
+			t.Errorf("[%d](%v).SplitAtLength(%f) (ends) failed. %v != %v",
+				h, cubic, length, first.End(), second.Begin())
+		}
+		pfirst, _ := param.SplitAtLength(length)
+		if l := pfirst.Length(); !IsEqual(l, length) {
+			t.Errorf("[%d](%v).SplitAtLength(%f) failed. %f != %f", h, param, length, l, length)
+		}
+	}
+
+	equidistantTests := []struct {
+		a   arcLengther
+		n   int
+		pts []Pt
+	}{
+		{
+			//0
+			line, 5,
+			[]Pt{PtXy(0, 0), PtXy(2.5, 0), PtXy(5, 0), PtXy(7.5, 0), PtXy(10, 0)},
+		}, {
+			doubled, 5,
+			[]Pt{PtXy(0, 0), PtXy(1, 0), PtXy(2, 0), PtXy(1, 0), PtXy(0, 0)},
+		}, {
+			line, 1,
+			[]Pt{PtXy(0, 0)},
+		}, {
+			line, 0,
+			nil,
+		},
+	}
+	for h, test := range equidistantTests {
+		pts := test.a.EquidistantPts(test.n)
+		if len(pts) != len(test.pts) {
+			t.Errorf("[%d](%v).EquidistantPts(%d) (length) failed. %v != %v",
+				h, test.a, test.n, pts, test.pts)
+			continue
+		}
+		for i := range pts {
+			if !IsEqualPair(pts[i], test.pts[i]) {
+				t.Errorf("[%d][%d](%v).EquidistantPts(%d) failed. %v != %v",
+					h, i, test.a, test.n, pts[i], test.pts[i])
+			}
+		}
+	}
+
+	// Every step along the curve is the same length.
+	table := cubic.ArcLengthTable(64)
+	pts := table.EquidistantPts(12)
+	step := table.Length() / 11
+	for h := 1; h < len(pts); h++ {
+		t1, _, _ := cubic.ClosestPt(pts[h-1])
+		t2, _, _ := cubic.ClosestPt(pts[h])
+		prev, curr := table.LengthAtT(t1), table.LengthAtT(t2)
+		if !IsEqual(curr-prev, step) {
+			t.Errorf("[%d](%v).EquidistantPts(12) failed. %f != %f", h, cubic, curr-prev, step)
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package figuring
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// bernsteinRootMaxDepth limits how many times a polynomial is clipped or
+// halved while finding its roots.
+const bernsteinRootMaxDepth = 64
+
+// Bernstein is a polynomial in the Bernstein basis over the range [0, 1], in
+// the form of f(t) = sum b_i * C(n, i) * t^i * (1-t)^(n-i). The coefficients
+// are the control values of a Bezier curve, so f(t) is evaluated with de
+// Casteljau, which is more stable than the power basis used by \c Cubic and
+// \c Quartic.
+//
+// The coefficients are not in the power basis, so Bernstein does not
+// implement Coefficienter. Use \c BernsteinFrom and \c PolynomialN to convert
+// between the two.
+type Bernstein struct {
+	coeffs []float64
+}
+
+// BernsteinCoefficients creates a Bernstein polynomial from its coefficients,
+// ordered from b_0 to b_n. A polynomial with n+1 coefficients has degree n.
+func BernsteinCoefficients(coeffs ...float64) Bernstein {
+	return Bernstein{coeffs: append([]float64{}, coeffs...)}
+}
+
+// BernsteinFrom creates the Bernstein polynomial equal to the power basis
+// polynomial \c c, such as a \c Cubic or a \c PolynomialN. The degree of the
+// result is the number of coefficients of \c c minus one.
+func BernsteinFrom(c Coefficienter) Bernstein {
+	// see https://en.wikipedia.org/wiki/Bernstein_polynomial#Transformation_to_the_monomial_basis
+	power := c.Coefficients()
+	n := len(power) - 1
+	if n < 0 {
+		return Bernstein{}
+	}
+	coeffs := make([]float64, n+1)
+	for i := range coeffs {
+		for j := 0; j <= i; j++ {
+			coeffs[i] += binomial(i, j) / binomial(n, j) * power[n-j]
+		}
+	}
+	return Bernstein{coeffs: coeffs}
+}
+
+// Antiderivative returns the antiderivative of the polynomial, in the
+// Bernstein basis with one more degree. The antiderivative is zero at t=0.
+func (b Bernstein) Antiderivative() Bernstein {
+	// see https://en.wikipedia.org/wiki/Bernstein_polynomial#Properties
+	n := b.Degree()
+	antideriv := make([]float64, n+2)
+	for h, c := range b.terms() {
+		antideriv[h+1] = antideriv[h] + c/float64(n+1)
+	}
+	return Bernstein{coeffs: antideriv}
+}
+
+// AtT evaluates the polynomial for the provided t value, using de Casteljau.
+func (b Bernstein) AtT(t float64) float64 {
+	left, _ := DeCasteljauSplit(b.pts(), t)
+	return float64(left[len(left)-1].Y())
+}
+
+// Controls returns the coefficients, ordered from b_0 to b_n. The slice is a
+// copy.
+func (b Bernstein) Controls() []float64 { return append([]float64{}, b.terms()...) }
+
+// Degree is the polynomial degree. Also known as the largest exponent.
+func (b Bernstein) Degree() int { return len(b.terms()) - 1 }
+
+// Derivative implements the Derivable interface. See \c FirstDerivative.
+func (b Bernstein) Derivative() Polynomial { return b.FirstDerivative() }
+
+// Elevate returns the same polynomial with more coefficients, raised to
+// \c degree. The polynomial is returned unchanged if it is already at or
+// above \c degree.
+func (b Bernstein) Elevate(degree int) Bernstein {
+	return bernsteinFromPts(ElevatePts(b.pts(), degree))
+}
+
+// FirstDerivative returns the derivative of the polynomial, in the Bernstein
+// basis with one less degree.
+func (b Bernstein) FirstDerivative() Bernstein {
+	n := b.Degree()
+	if n == 0 {
+		return BernsteinCoefficients(0)
+	}
+	deriv := make([]float64, n)
+	for h := range deriv {
+		deriv[h] = float64(n) * (b.terms()[h+1] - b.terms()[h])
+	}
+	return Bernstein{coeffs: deriv}
+}
+
+// PolynomialN converts the polynomial into the power basis.
+func (b Bernstein) PolynomialN() PolynomialN {
+	// see https://en.wikipedia.org/wiki/Bernstein_polynomial#Transformation_to_the_monomial_basis
+	n := b.Degree()
+	power := make([]float64, n+1)
+	for j := 0; j <= n; j++ {
+		var sum float64
+		for i := 0; i <= j; i++ {
+			sign := 1.0
+			if (j-i)%2 == 1 {
+				sign = -1
+			}
+			sum += sign * binomial(j, i) * b.terms()[i]
+		}
+		power[n-j] = binomial(n, j) * sum
+	}
+	return polynomialN(power)
+}
+
+// RootBounds returns the range of t values where the convex hull of the
+// control points (i/n, b_i) touches zero. Every root between 0 and 1 is
+// inside the range. Returns false if the hull doesn't touch zero, so there
+// are no roots between 0 and 1.
+func (b Bernstein) RootBounds() (float64, float64, bool) {
+	// The hull meets zero between the crossings of every pair of control
+	// points on different sides of zero.
+	n := b.Degree()
+	if n == 0 {
+		return 0, 0, false
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i, bi := range b.terms() {
+		ti := float64(i) / float64(n)
+		if bi == 0 {
+			lo, hi = math.Min(lo, ti), math.Max(hi, ti)
+			continue
+		}
+		for j := i + 1; j <= n; j++ {
+			bj := b.terms()[j]
+			if bj == 0 || math.Signbit(bi) == math.Signbit(bj) {
+				continue
+			}
+			tj := float64(j) / float64(n)
+			t := ti + (tj-ti)*bi/(bi-bj)
+			lo, hi = math.Min(lo, t), math.Max(hi, t)
+		}
+	}
+	if lo > hi {
+		return 0, 0, false
+	}
+	return lo, hi, true
+}
+
+// Roots returns the real roots of the polynomial between 0 and 1, sorted
+// from least to greatest. Repeated roots are only returned once.
+//
+// The range is clipped to \c RootBounds, and halved when clipping doesn't
+// shrink it enough, until the range is smaller than the precision. Roots
+// where the polynomial touches zero without crossing it are found from the
+// roots of the derivative.
+func (b Bernstein) Roots() []float64 {
+	// see Sederberg and Nishita, Curve intersection using Bezier clipping.
+	if b.isZero() {
+		return nil
+	}
+	var roots []float64
+	var clip func(eq Bernstein, lo, hi float64, depth int)
+	clip = func(eq Bernstein, lo, hi float64, depth int) {
+		a, z, ok := eq.RootBounds()
+		if !ok {
+			return
+		}
+		tlo, thi := lo+(hi-lo)*a, lo+(hi-lo)*z
+		if thi-tlo <= 1e-15*math.Max(1, math.Abs(tlo)) || depth >= bernsteinRootMaxDepth {
+			roots = append(roots, tlo+(thi-tlo)/2)
+			return
+		}
+		eq = eq.segment(a, z)
+		if z-a > 0.8 {
+			left, right := eq.SplitAtT(0.5)
+			mid := tlo + (thi-tlo)/2
+			clip(left, tlo, mid, depth+1)
+			clip(right, mid, thi, depth+1)
+			return
+		}
+		clip(eq, tlo, thi, depth+1)
+	}
+	clip(b, 0, 1, 0)
+
+	// A root where the polynomial only touches zero may not cross it after
+	// rounding, so clipping can miss it. Those roots are also roots of the
+	// derivative.
+	var tolerance float64
+	for _, c := range b.terms() {
+		tolerance = math.Max(tolerance, math.Abs(c))
+	}
+	tolerance *= 1e-14
+	if b.Degree() > 1 {
+		for _, t := range b.FirstDerivative().Roots() {
+			if math.Abs(b.AtT(t)) <= tolerance {
+				roots = append(roots, t)
+			}
+		}
+	}
+
+	// Repeated roots are found as a cluster of nearby roots. Neighbors are
+	// the same root when the polynomial stays at zero between them.
+	sort.Float64s(roots)
+	var unique []float64
+	for h := 0; h < len(roots); {
+		first, last := roots[h], roots[h]
+		for h++; h < len(roots); h++ {
+			t := roots[h]
+			if t-last > 1e-9 && math.Abs(b.AtT(last+(t-last)/2)) > tolerance {
+				break
+			}
+			last = t
+		}
+		unique = append(unique, first+(last-first)/2)
+	}
+	return unique
+}
+
+// SplitAtT returns the two polynomials that are equal to this one over
+// [0, t] and [t, 1], each reparameterized over [0, 1].
+func (b Bernstein) SplitAtT(t float64) (Bernstein, Bernstein) {
+	left, right := DeCasteljauSplit(b.pts(), t)
+	return bernsteinFromPts(left), bernsteinFromPts(right)
+}
+
+// String returns the formula of the polynomial.
+func (b Bernstein) String() string { return b.Text('t', true) }
+
+// Text returns a string representing the polynomial. B_i,n is the Bernstein
+// basis polynomial i of degree n. See \c Polynomial.
+func (b Bernstein) Text(unknown rune, addPrefix bool) string {
+	var sb strings.Builder
+	if addPrefix {
+		fmt.Fprintf(&sb, "f(%c)=", unknown)
+	}
+	n := b.Degree()
+	for h, c := range b.terms() {
+		if h > 0 {
+			op := '+'
+			if c < 0 {
+				op = '-'
+				c = -c
+			}
+			sb.WriteRune(op)
+		}
+		fmt.Fprintf(&sb, "%sB%d,%d(%c)", HumanFormat(9, c), h, n, unknown)
+	}
+	return sb.String()
+}
+
+// Integral implements the Integrable interface. See \c Antiderivative.
+func (b Bernstein) Integral() Polynomial { return b.Antiderivative() }
+
+// isZero returns true if every coefficient is zero.
+func (b Bernstein) isZero() bool {
+	for _, c := range b.terms() {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// pts returns the control points of the graph of the polynomial, (i/n, b_i).
+func (b Bernstein) pts() []Pt {
+	n := b.Degree()
+	pts := make([]Pt, n+1)
+	for h, c := range b.terms() {
+		var t float64
+		if n > 0 {
+			t = float64(h) / float64(n)
+		}
+		pts[h] = PtXy(Length(t), Length(c))
+	}
+	return pts
+}
+
+// segment returns the polynomial that is equal to this one over [t0, t1],
+// reparameterized over [0, 1].
+func (b Bernstein) segment(t0, t1 float64) Bernstein {
+	if t1 < 1 {
+		b, _ = b.SplitAtT(t1)
+	}
+	if t0 > 0 && t1 > 0 {
+		_, b = b.SplitAtT(t0 / t1)
+	}
+	return b
+}
+
+// terms returns the coefficients, treating the zero value as the zero
+// polynomial.
+func (b Bernstein) terms() []float64 {
+	if len(b.coeffs) == 0 {
+		return []float64{0}
+	}
+	return b.coeffs
+}
+
+// bernsteinFromPts creates a Bernstein polynomial from the y values of the
+// control points of its graph.
+func bernsteinFromPts(pts []Pt) Bernstein {
+	coeffs := make([]float64, len(pts))
+	for h, p := range pts {
+		coeffs[h] = float64(p.Y())
+	}
+	return Bernstein{coeffs: coeffs}
+}
+
+// binomial returns the binomial coefficient n choose k.
+func binomial(n, k int) float64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	c := 1.0
+	for h := 1; h <= k; h++ {
+		c = c * float64(n-k+h) / float64(h)
+	}
+	return c
+}
@@ -0,0 +1,184 @@
+package figuring
+
+import (
+	"testing"
+)
+
+func TestBernstein(t *testing.T) {
+	bn := BernsteinCoefficients
+	pn := PolynomialNCoefficients
+	identityTests := []struct {
+		eq         Bernstein
+		s          string
+		degree     int
+		power      PolynomialN
+		roots      []float64
+		derivative Bernstein
+	}{
+		{
+			//0
+			bn(1, 2, 3), "f(t)=1B0,2(t)+2B1,2(t)+3B2,2(t)", 2,
+			pn(2, 1), nil, bn(2, 2),
+		}, {
+			bn(-1, 1), "f(t)=-1B0,1(t)+1B1,1(t)", 1,
+			pn(2, -1), []float64{0.5}, bn(2),
+		}, {
+			// The cubic bezier control values 0, 1, -1, 0.
+			bn(0, 1, -1, 0), "f(t)=0B0,3(t)+1B1,3(t)-1B2,3(t)+0B3,3(t)", 3,
+			pn(6, -9, 3, 0), []float64{0, 0.5, 1}, bn(3, -6, 3),
+		}, {
+			bn(4), "f(t)=4B0,0(t)", 0,
+			pn(4), nil, bn(0),
+		}, {
+			Bernstein{}, "f(t)=0B0,0(t)", 0,
+			pn(0), nil, bn(0),
+		}, {
+			//5
+			// (t-0.25)(t-0.75)
+			BernsteinFrom(QuadraticAbc(1, -1, 0.1875)), "f(t)=0.1875B0,2(t)-0.3125B1,2(t)+0.1875B2,2(t)", 2,
+			pn(1, -1, 0.1875), []float64{0.25, 0.75}, bn(-1, 1),
+		}, {
+			// (t-0.5)^2, touches zero.
+			BernsteinFrom(QuadraticAbc(1, -1, 0.25)), "f(t)=0.25B0,2(t)-0.25B1,2(t)+0.25B2,2(t)", 2,
+			pn(1, -1, 0.25), []float64{0.5}, bn(-1, 1),
+		}, {
+			// Close roots stay separate.
+			BernsteinFrom(pn(1, -0.3).Mul(pn(1, -0.3001))), "f(t)=0.09003B0,2(t)-0.21002B1,2(t)+0.48993B2,2(t)", 2,
+			pn(1, -0.6001, 0.09003), []float64{0.3, 0.3001}, bn(-0.6001, 1.3999),
+		},
+	}
+	for h, test := range identityTests {
+		eq := test.eq
+		if s := eq.String(); s != test.s {
+			t.Errorf("[%d](%v).String() failed. %s != %s", h, eq, s, test.s)
+		}
+		if degree := eq.Degree(); degree != test.degree {
+			t.Errorf("[%d](%v).Degree() failed. %d != %d", h, eq, degree, test.degree)
+		}
+		if power := eq.PolynomialN(); !IsEqualEquations(power, test.power) {
+			t.Errorf("[%d](%v).PolynomialN() failed. %v != %v", h, eq, power, test.power)
+		}
+		for _, tv := range []float64{-1, 0, 0.35, 0.5, 1, 2} {
+			if v, e := eq.AtT(tv), test.power.AtT(tv); !IsEqual(v, e) {
+				t.Errorf("[%d](%v).AtT(%f) failed. %f != %f", h, eq, tv, v, e)
+			}
+		}
+		roots := eq.Roots()
+		if len(roots) != len(test.roots) {
+			t.Errorf("[%d](%v).Roots() length failed. %v != %v", h, eq, roots, test.roots)
+		} else {
+			for i := range roots {
+				if !IsEqual(roots[i], test.roots[i]) {
+					t.Errorf("[%d][%d](%v).Roots() failed. %f != %f", h, i, eq, roots[i], test.roots[i])
+				}
+			}
+		}
+		var poly Polynomial = eq
+		if _, ok := poly.(Derivable); !ok {
+			t.Errorf("[%d](%v).Derivative() failed. couldn't be converted for %T", h, eq, eq)
+		} else if deq := eq.FirstDerivative(); !isEqualBernstein(deq, test.derivative) {
+			t.Errorf("[%d](%v).FirstDerivative() failed. %v != %v", h, eq, deq, test.derivative)
+		}
+	}
+
+	// Roots that only touch zero are not lost to rounding.
+	rootTests := []struct {
+		eq    Bernstein
+		roots []float64
+	}{
+		{
+			// (t-0.1)^2(t-0.3)^2(t-0.7)^2
+			BernsteinFrom(pn(1, -0.1).Mul(pn(1, -0.1)).Mul(pn(1, -0.3)).Mul(pn(1, -0.3)).Mul(pn(1, -0.7)).Mul(pn(1, -0.7))),
+			[]float64{0.1, 0.3, 0.7},
+		}, {
+			// (t-0.2)^2(t-0.6)
+			BernsteinFrom(pn(1, -0.2).Mul(pn(1, -0.2)).Mul(pn(1, -0.6))),
+			[]float64{0.2, 0.6},
+		}, {
+			// (t-0.5)^2+0.01 stays above zero.
+			BernsteinFrom(pn(1, -1, 0.26)),
+			nil,
+		},
+	}
+	for h, test := range rootTests {
+		roots := test.eq.Roots()
+		if len(roots) != len(test.roots) {
+			t.Errorf("[%d](%v).Roots() length failed. %v != %v", h, test.eq, roots, test.roots)
+			continue
+		}
+		for i := range roots {
+			if !IsEqual(roots[i], test.roots[i]) {
+				t.Errorf("[%d][%d](%v).Roots() failed. %f != %f", h, i, test.eq, roots[i], test.roots[i])
+			}
+		}
+	}
+
+	// Conversions are exact in both directions.
+	conversionTests := []Coefficienter{
+		CubicAbcd(-1, 3, 13, 2),
+		QuarticAbcde(1, -2, 3, -4, 5),
+		QuadraticAbc(0, 2, -1),
+		pn(1, -3, -5, 15, 4, -12),
+	}
+	for h, test := range conversionTests {
+		power := PolynomialNFrom(test)
+		if back := BernsteinFrom(test).PolynomialN(); !IsEqualEquations(back, power) {
+			t.Errorf("[%d]BernsteinFrom(%v).PolynomialN() failed. %v != %v", h, test, back, power)
+		}
+	}
+	// The control values of a bezier match the power basis from the matrix.
+	bezier := BezierPt(PtXy(0, 0), PtXy(1, 3), PtXy(4, -2), PtXy(6, 1))
+	if x := BernsteinCoefficients(0, 1, 4, 6).PolynomialN(); !IsEqualEquations(x, PolynomialNFrom(bezier.x)) {
+		t.Errorf("(%v).PolynomialN() failed. %v != %v", bezier, x, bezier.x)
+	}
+
+	// Elevation and splitting keep the same polynomial.
+	eq := bn(0, 1, -1, 0)
+	elevated := eq.Elevate(5)
+	if elevated.Degree() != 5 || !IsEqualEquations(elevated.PolynomialN(), eq.PolynomialN()) {
+		t.Errorf("(%v).Elevate(5) failed. %v", eq, elevated)
+	}
+	if same := eq.Elevate(2); !isEqualBernstein(same, eq) {
+		t.Errorf("(%v).Elevate(2) failed. %v != %v", eq, same, eq)
+	}
+	left, right := eq.SplitAtT(0.25)
+	for _, tv := range []float64{0, 0.4, 1} {
+		if l, e := left.AtT(tv), eq.AtT(tv*0.25); !IsEqual(l, e) {
+			t.Errorf("(%v).SplitAtT(0.25) left failed at %f. %f != %f", eq, tv, l, e)
+		}
+		if r, e := right.AtT(tv), eq.AtT(0.25+tv*0.75); !IsEqual(r, e) {
+			t.Errorf("(%v).SplitAtT(0.25) right failed at %f. %f != %f", eq, tv, r, e)
+		}
+	}
+
+	boundTests := []struct {
+		eq     Bernstein
+		lo, hi float64
+		ok     bool
+	}{
+		{bn(-1, 1), 0.5, 0.5, true},
+		{bn(1, 2, 3), 0, 0, false},
+		{bn(-1, 1, 1, 1), 1.0 / 6, 0.5, true},
+		{bn(0, 1, -1, 0), 0, 1, true},
+	}
+	for h, test := range boundTests {
+		lo, hi, ok := test.eq.RootBounds()
+		if ok != test.ok || (ok && (!IsEqual(lo, test.lo) || !IsEqual(hi, test.hi))) {
+			t.Errorf("[%d](%v).RootBounds() failed. %f, %f, %t != %f, %f, %t",
+				h, test.eq, lo, hi, ok, test.lo, test.hi, test.ok)
+		}
+	}
+}
+
+func isEqualBernstein(a, b Bernstein) bool {
+	as, bs := a.Controls(), b.Controls()
+	if len(as) != len(bs) {
+		return false
+	}
+	for h := range as {
+		if !IsEqual(as[h], bs[h]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,360 @@
+package figuring
+
+import (
+	"math"
+)
+
+const (
+	// arcBezierMaxPieces limits how many curves an arc is split into when the
+	// tolerance is smaller than the floating point error of the curves.
+	arcBezierMaxPieces = 1024
+	// biarcMaxDepth limits how many times a curve is halved while fitting
+	// biarcs.
+	biarcMaxDepth = 16
+)
+
+// BeziersFromArc approximates the arc with cubic Bezier curves that stray no
+// more than \c tolerance from the arc. The arc is split into equal pieces,
+// using as few pieces as possible. Returns the curves and the largest
+// distance between the curves and the arc.
+//
+// The tolerance must be greater than zero. Returns nil and a NaN deviation if
+// the tolerance or arc are in error.
+func BeziersFromArc(a Arc, tolerance Length) ([]Bezier, Length) {
+	if _, err := a.OrErr(); err != nil || !(tolerance > 0) {
+		return nil, Length(math.NaN())
+	}
+	if IsZero(a.r) || IsZero(a.sweep) {
+		p := a.Begin()
+		return []Bezier{BezierPt(p, p, p, p)}, 0
+	}
+
+	n := int(math.Ceil(math.Abs(float64(a.sweep)) / (math.Pi / 2)))
+	piece := arcBezier(a.c, a.r, a.start, a.sweep/Radians(n))
+	deviation := arcBezierDeviation(a.c, a.r, piece)
+	for deviation > tolerance && n < arcBezierMaxPieces {
+		n++
+		piece = arcBezier(a.c, a.r, a.start, a.sweep/Radians(n))
+		deviation = arcBezierDeviation(a.c, a.r, piece)
+	}
+
+	// Every piece is the same curve rotated around the center, so they all
+	// share the same deviation.
+	curves := make([]Bezier, 0, n)
+	sweep := a.sweep / Radians(n)
+	for h := 0; h < n; h++ {
+		curves = append(curves, arcBezier(a.c, a.r, a.start+sweep*Radians(h), sweep))
+	}
+	return curves, deviation
+}
+
+// arcBezier returns the standard cubic approximation of the arc around \c c
+// from \c start through \c sweep radians.
+func arcBezier(c Pt, r Length, start, sweep Radians) Bezier {
+	// see https://pomax.github.io/bezierinfo/#circles_cubic
+	k := r * Length(4.0/3.0*math.Tan(float64(sweep)/4))
+	end := start + sweep
+	p1 := c.Add(VectorFromTheta(start).Scale(r))
+	p4 := c.Add(VectorFromTheta(end).Scale(r))
+	p2 := p1.Add(VectorFromTheta(start + math.Pi/2).Scale(k))
+	p3 := p4.Add(VectorFromTheta(end + math.Pi/2).Scale(-k))
+	return BezierPt(p1, p2, p3, p4)
+}
+
+// arcBezierDeviation returns the largest distance between \c curve and the
+// circle around \c c with radius \c r.
+func arcBezierDeviation(c Pt, r Length, curve Bezier) Length {
+	dx, dy := bezierFromCenter(c, curve)
+	return radialDeviation(dx, dy, r)
+}
+
+// bezierFromCenter returns the polynomials of the vector from \c c to the
+// points of the curve.
+func bezierFromCenter(c Pt, curve Bezier) (PolynomialN, PolynomialN) {
+	cx, cy := c.XY()
+	dx := PolynomialNFrom(curve.x).Sub(PolynomialNCoefficients(float64(cx)))
+	dy := PolynomialNFrom(curve.y).Sub(PolynomialNCoefficients(float64(cy)))
+	return dx, dy
+}
+
+// radialDeviation returns the largest difference between the length of the
+// vector (dx, dy) and \c r, for t in [0, 1].
+func radialDeviation(dx, dy PolynomialN, r Length) Length {
+	// The squared distance from the center is a polynomial of degree 6, so
+	// the distance is furthest from the radius at the ends of the curve or
+	// at the roots of the derivative.
+	sq := dx.Mul(dx).Add(dy.Mul(dy))
+	deviation := func(t float64) Length {
+		d := Length(math.Sqrt(sq.AtT(t))) - r
+		if d < 0 {
+			return -d
+		}
+		return d
+	}
+	worst := Maximum(deviation(0), deviation(1))
+	for _, root := range sq.FirstDerivative().RealRoots(0, 1) {
+		worst = Maximum(worst, deviation(root.T))
+	}
+	return worst
+}
+
+// ArcsFromBezier approximates the curve with arcs that stray no more than
+// \c tolerance from the curve. Neighboring arcs meet with the same tangent, so
+// the arcs can be used directly as G2/G3 moves. The curve is fit with
+// biarcs, and halved until the deviation of each piece is within tolerance.
+// Returns the arcs and the deviation.
+//
+// Portions of the curve that are straight within tolerance, and can't be fit
+// by a biarc, are replaced by shallow arcs, because an arc can not be
+// straight. The tangents are not continuous around those arcs.
+//
+// The deviation is a bound on the distance between the curve and the arcs,
+// in both directions. Each arc is paired with the span of the curve in the
+// same directions from its center, and bounded the same way as
+// \c BeziersFromArc. When a span can't be paired, the bound is the size of
+// the span. A piece that is still not within tolerance after being halved
+// many times, such as around a cusp, is kept anyway, and the returned
+// deviation is greater than \c tolerance. The tolerance must be greater than
+// zero. Returns nil and a NaN deviation if the tolerance or curve are in
+// error.
+func ArcsFromBezier(curve Bezier, tolerance Length) ([]Arc, Length) {
+	if !(tolerance > 0) {
+		return nil, Length(math.NaN())
+	}
+	for _, p := range curve.pts {
+		if _, err := p.OrErr(); err != nil {
+			return nil, Length(math.NaN())
+		}
+	}
+	return appendBiarcs(nil, curve, tolerance, 0)
+}
+
+// appendBiarcs fits the curve with arcs, halving it when the fit is not
+// within tolerance, and appends the arcs to \c arcs.
+func appendBiarcs(arcs []Arc, curve Bezier, tolerance Length, depth int) ([]Arc, Length) {
+	chord := SegmentPt(curve.pts[0], curve.pts[3])
+	// The curve is inside the hull of its control points, so it is no
+	// further from the chord than they are.
+	var straightness Length
+	for _, p := range curve.pts[1:3] {
+		_, _, d := chord.ClosestPt(p)
+		straightness = Maximum(straightness, d)
+	}
+	if IsZero(chord.Length()) && straightness <= tolerance {
+		// Nothing to draw.
+		return arcs, straightness
+	}
+
+	// Biarcs keep the tangents continuous, so only fall back to a shallow
+	// arc when the biarc doesn't fit.
+	var fit []Arc
+	deviation := Length(math.Inf(1))
+	if biarc, ok := fitBiarc(curve); ok {
+		fit, deviation = biarc, biarcDeviation(curve, biarc)
+	}
+	if deviation > tolerance && straightness <= tolerance/2 {
+		if arc, ok := shallowArc(curve, tolerance/2); ok {
+			if d := arcSpanDeviation(curve, arc); d < deviation {
+				fit, deviation = []Arc{arc}, d
+			}
+		}
+	}
+	if deviation <= tolerance || depth >= biarcMaxDepth {
+		if fit == nil {
+			// Unable to fit the smallest piece, so connect the ends with
+			// the closest thing to a straight line.
+			arc, ok := shallowArc(curve, tolerance/2)
+			if !ok {
+				// The ends are too close for an arc, so the piece is
+				// left to the neighboring arcs.
+				return arcs, straightness + chord.Length()
+			}
+			fit = []Arc{arc}
+			deviation = arcSpanDeviation(curve, arc)
+		}
+		return append(arcs, fit...), deviation
+	}
+	first, second := curve.SplitAtT(0.5)
+	arcs, d1 := appendBiarcs(arcs, first, tolerance, depth+1)
+	arcs, d2 := appendBiarcs(arcs, second, tolerance, depth+1)
+	return arcs, Maximum(d1, d2)
+}
+
+// shallowArc returns the arc from the beginning to the end of the curve that
+// bulges \c sagitta away from the chord, on the same side as the middle of
+// the curve.
+func shallowArc(curve Bezier, sagitta Length) (Arc, bool) {
+	begin, end := curve.pts[0], curve.pts[3]
+	chord := begin.VectorTo(end)
+	length := chord.Magnitude()
+	if IsZero(length) || IsZero(sagitta) {
+		return Arc{}, false
+	}
+	ci, cj := chord.Units()
+	normal := VectorIj(-cj/length, ci/length)
+	if crossPts(begin, end, curve.PtAtT(0.5)) < 0 {
+		normal = normal.Invert()
+	}
+	mid := PtXy((begin.X()+end.X())/2, (begin.Y()+end.Y())/2).Add(normal.Scale(sagitta))
+	arc := ArcFromPts(begin, mid, end)
+	if _, err := arc.OrErr(); err != nil {
+		return arc, false
+	}
+	return arc, true
+}
+
+// fitBiarc returns the two arcs that start and end with the same points and
+// tangents as the curve, and meet each other with the same tangent. Returns
+// false when no such arcs exist.
+func fitBiarc(curve Bezier) ([]Arc, bool) {
+	// see https://www.ryanjuckett.com/biarc-interpolation/
+	p1, p2 := curve.pts[0], curve.pts[3]
+	t1, ok1 := bezierEndTangent(curve.pts[0], curve.pts[1], curve.pts[2], curve.pts[3])
+	t2, ok2 := bezierEndTangent(curve.pts[3], curve.pts[2], curve.pts[1], curve.pts[0])
+	if !ok1 || !ok2 {
+		return nil, false
+	}
+	t2 = t2.Invert()
+
+	v := p1.VectorTo(p2)
+	t := t1.Add(t2)
+	vt, vv := v.Dot(t), v.Dot(v)
+	denom := 2 * (1 - t1.Dot(t2))
+
+	var d Length
+	if IsZero(denom) {
+		// Parallel tangents pointing the same way.
+		vt2 := v.Dot(t2)
+		if IsZero(vt2) {
+			return nil, false
+		}
+		d = vv / (4 * vt2)
+	} else {
+		d = (-vt + Length(math.Sqrt(float64(vt*vt+denom*vv)))) / denom
+	}
+	if d <= 0 || IsZero(d) {
+		return nil, false
+	}
+
+	q1, q2 := p1.Add(t1.Scale(d)), p2.Add(t2.Scale(-d))
+	joint := PtXy((q1.X()+q2.X())/2, (q1.Y()+q2.Y())/2)
+	first, ok := arcFromTangent(p1, t1, joint)
+	if !ok {
+		return nil, false
+	}
+	second, ok := arcFromTangent(p2, t2.Invert(), joint)
+	if !ok {
+		return nil, false
+	}
+	return []Arc{first, second.Reverse()}, true
+}
+
+// bezierEndTangent returns the unit tangent leaving \c p, using the next
+// distinct control point.
+func bezierEndTangent(p Pt, next ...Pt) (Vector, bool) {
+	for _, n := range next {
+		if v := p.VectorTo(n); !IsZero(v.Magnitude()) {
+			return v.Normalize(), true
+		}
+	}
+	return Vector{}, false
+}
+
+// arcFromTangent returns the arc that leaves \c p in the direction of the
+// unit \c tangent and ends at \c q. Returns false if the arc would be a
+// straight line.
+func arcFromTangent(p Pt, tangent Vector, q Pt) (Arc, bool) {
+	ti, tj := tangent.Units()
+	normal := VectorIj(-tj, ti)
+	pq := p.VectorTo(q)
+	nd := normal.Dot(pq)
+	if IsZero(nd) || IsZero(pq.Magnitude()) {
+		return Arc{}, false
+	}
+	// Signed radius, positive when the arc turns left.
+	r := pq.Dot(pq) / (2 * nd)
+	c := p.Add(normal.Scale(r))
+	start := c.VectorTo(p).Angle()
+	stop := c.VectorTo(q).Angle()
+	sweep := (stop - start).Normalize()
+	if r < 0 {
+		// Turning right, so the arc is clockwise.
+		r = -r
+		sweep = -(start - stop).Normalize()
+	}
+	return ArcCenter(c, r, start, sweep), true
+}
+
+// biarcDeviation returns a bound on the distance between the curve and the
+// biarc. The centers of the arcs are on the line through the joint, so the
+// curve is split where it crosses that line, and each span is bounded
+// against its arc. The crossing with the smallest bound is used.
+func biarcDeviation(curve Bezier, biarc []Arc) Length {
+	joint := biarc[0].End()
+	ni, nj := biarc[0].c.VectorTo(joint).Units()
+	dx, dy := bezierFromCenter(joint, curve)
+	across := dy.Scale(float64(ni)).Sub(dx.Scale(float64(nj)))
+	splits := across.RealRoots(0, 1)
+	if len(splits) == 0 {
+		splits = []Root{{T: 0.5, Multiplicity: 1}}
+	}
+	best := Length(math.Inf(1))
+	for _, split := range splits {
+		first, second := curve.SplitAtT(split.T)
+		d := Maximum(arcSpanDeviation(first, biarc[0]), arcSpanDeviation(second, biarc[1]))
+		best = Minimum(best, d)
+	}
+	return best
+}
+
+// arcSpanDeviation returns a bound on the distance between the arc and the
+// span of a curve. When the direction from the center to the span turns
+// steadily from the beginning of the arc to the end, every point of the span
+// pairs with the point of the arc in the same direction, and the bound is
+// exact. Otherwise the bound is the diagonal of the box around both.
+func arcSpanDeviation(span Bezier, a Arc) Length {
+	dx, dy := bezierFromCenter(a.c, span)
+	if arcSpanPairs(span, a, dx, dy) {
+		return radialDeviation(dx, dy, a.r)
+	}
+	lx, mx, ly, my := LimitsPts(append(span.Points(), a.BoundingBox().Points()...))
+	return PtXy(lx, ly).VectorTo(PtXy(mx, my)).Magnitude()
+}
+
+// arcSpanPairs returns true when the direction from the center of the arc to
+// the span starts and ends with the directions of the ends of the arc, and
+// turns steadily with the arc without any extra turns. The polynomials
+// \c dx and \c dy are the vector from the center to the span.
+func arcSpanPairs(span Bezier, a Arc, dx, dy PolynomialN) bool {
+	if IsZero(a.r) || IsZero(a.sweep) ||
+		!sameDirection(a.c.VectorTo(span.Begin()), a.c.VectorTo(a.Begin())) ||
+		!sameDirection(a.c.VectorTo(span.End()), a.c.VectorTo(a.End())) {
+		return false
+	}
+	// The direction turns steadily while its cross product with the tangent
+	// keeps the same sign. Touching zero, or being zero at the ends where
+	// a control point is repeated, doesn't change the direction of the turn.
+	turn := dx.Mul(dy.FirstDerivative()).Sub(dy.Mul(dx.FirstDerivative()))
+	for _, root := range turn.RealRoots(0, 1) {
+		if root.Multiplicity%2 == 1 && !IsZero(root.T) && !IsZero(1-root.T) {
+			return false
+		}
+	}
+	if turn.AtT(0.5) > 0 != (a.sweep > 0) {
+		return false
+	}
+	// A steady turn between the ends of the arc is the sweep plus some
+	// number of full turns, and only the sweep alone crosses the line
+	// through the middle of the arc once.
+	mi, mj := VectorFromTheta(a.start + a.sweep/2).Units()
+	middle := dy.Scale(float64(mi)).Sub(dx.Scale(float64(mj)))
+	return len(middle.RealRoots(0, 1)) == 1
+}
+
+// sameDirection returns true when the vectors point the same way.
+func sameDirection(v, u Vector) bool {
+	vi, vj := v.Units()
+	ui, uj := u.Units()
+	cross := (vi*uj - vj*ui) / (v.Magnitude() * u.Magnitude())
+	return v.Dot(u) > 0 && IsZero(cross)
+}
@@ -0,0 +1,169 @@
+package figuring
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBeziersFromArc(t *testing.T) {
+	quarter := ArcCenter(PtXy(0, 0), 1, 0, math.Pi/2)
+	clockwise := ArcCenter(PtXy(1, 1), 2, math.Pi/2, -3*math.Pi/2)
+
+	tests := []struct {
+		a         Arc
+		tolerance Length
+		count     int
+		deviation Length
+	}{
+		{
+			//0
+			quarter, 1e-3, 1, 0.000272530007428,
+		}, {
+			quarter, 1e-6, 3, 3.72661931536e-07,
+		}, {
+			clockwise, 1e-3, 3, 0.000545060014856,
+		}, {
+			ArcCenter(PtXy(0, 0), 1, 0, 2*math.Pi), 1e-3, 4, 0.000272530007428,
+		}, {
+			ArcCenter(PtXy(2, 2), 0, 0, math.Pi), 1e-3, 1, 0,
+		},
+	}
+	for h, test := range tests {
+		a := test.a
+		curves, deviation := BeziersFromArc(a, test.tolerance)
+		if len(curves) != test.count {
+			t.Errorf("[%d]BeziersFromArc(%v, %f) (count) failed. %d != %d",
+				h, a, test.tolerance, len(curves), test.count)
+			continue
+		}
+		if !IsEqual(deviation, test.deviation) || deviation > test.tolerance {
+			t.Errorf("[%d]BeziersFromArc(%v, %f) (deviation) failed. %v != %v",
+				h, a, test.tolerance, deviation, test.deviation)
+		}
+		if !IsEqualPair(curves[0].Begin(), a.Begin()) || !IsEqualPair(curves[len(curves)-1].End(), a.End()) {
+			t.Errorf("[%d]BeziersFromArc(%v, %f) (ends) failed. %v",
+				h, a, test.tolerance, curves)
+		}
+		for i := 1; i < len(curves); i++ {
+			if !IsEqualPair(curves[i-1].End(), curves[i].Begin()) {
+				t.Errorf("[%d][%d]BeziersFromArc(%v, %f) (continuity) failed. %v != %v",
+					h, i, a, test.tolerance, curves[i-1].End(), curves[i].Begin())
+			}
+		}
+		// The deviation is a bound on the distance from the circle.
+		for i, curve := range curves {
+			for j := 0; j <= 1000; j++ {
+				d := a.Center().VectorTo(curve.PtAtT(float64(j)/1000)).Magnitude() - a.Radius()
+				if math.Abs(float64(d)) > float64(deviation)*(1+1e-9) {
+					t.Errorf("[%d][%d]BeziersFromArc(%v, %f) (sample) failed. %v > %v",
+						h, i, a, test.tolerance, d, deviation)
+				}
+			}
+		}
+	}
+
+	if curves, deviation := BeziersFromArc(quarter, 0); curves != nil || !math.IsNaN(float64(deviation)) {
+		t.Errorf("BeziersFromArc() with zero tolerance failed. %v, %v", curves, deviation)
+	}
+}
+
+func TestArcsFromBezier(t *testing.T) {
+	tests := []struct {
+		curve     Bezier
+		tolerance Length
+		count     int
+	}{
+		{
+			//0
+			BezierPt(PtXy(1, 0), PtXy(1, 0.552284749831), PtXy(0.552284749831, 1), PtXy(0, 1)), 1e-3, 2,
+		}, {
+			BezierPt(PtXy(0, 0), PtXy(1, 2), PtXy(3, 2), PtXy(4, 0)), 1e-3, 16,
+		}, {
+			BezierPt(PtXy(0, 0), PtXy(1, 1), PtXy(2, -1), PtXy(3, 0)), 1e-3, 16,
+		}, {
+			BezierPt(PtXy(0, 0), PtXy(1, 0), PtXy(2, 0), PtXy(3, 0)), 1e-6, 1,
+		}, {
+			BezierPt(PtXy(0, 0), PtXy(1, 2), PtXy(3, 2), PtXy(4, 0)), 1e-6, 120,
+		},
+	}
+	for h, test := range tests {
+		c := test.curve
+		arcs, deviation := ArcsFromBezier(c, test.tolerance)
+		if len(arcs) != test.count {
+			t.Errorf("[%d]ArcsFromBezier(%v, %f) (count) failed. %d != %d",
+				h, c, test.tolerance, len(arcs), test.count)
+			continue
+		}
+		if deviation > test.tolerance {
+			t.Errorf("[%d]ArcsFromBezier(%v, %f) (deviation) failed. %v > %v",
+				h, c, test.tolerance, deviation, test.tolerance)
+		}
+		if !IsEqualPair(arcs[0].Begin(), c.Begin()) || !IsEqualPair(arcs[len(arcs)-1].End(), c.End()) {
+			t.Errorf("[%d]ArcsFromBezier(%v, %f) (ends) failed. %v",
+				h, c, test.tolerance, arcs)
+		}
+		for i := 1; i < len(arcs); i++ {
+			if !IsEqualPair(arcs[i-1].End(), arcs[i].Begin()) {
+				t.Errorf("[%d][%d]ArcsFromBezier(%v, %f) (continuity) failed. %v != %v",
+					h, i, c, test.tolerance, arcs[i-1].End(), arcs[i].Begin())
+			}
+		}
+		// Independently check the curve stays near the arcs.
+		for i := 0; i <= 100; i++ {
+			p := c.PtAtT(float64(i) / 100)
+			best := Length(math.Inf(1))
+			for _, a := range arcs {
+				_, _, d := a.ClosestPt(p)
+				best = Minimum(best, d)
+			}
+			if best > deviation*(1+1e-6) {
+				t.Errorf("[%d][%d]ArcsFromBezier(%v, %f) (sample) failed. %v > %v",
+					h, i, c, test.tolerance, best, deviation)
+			}
+		}
+		// And the arcs stay near the curve.
+		for i, a := range arcs {
+			for j := 0; j <= 10; j++ {
+				_, _, d := c.ClosestPt(a.PtAtT(float64(j) / 10))
+				if d > deviation*(1+1e-6) {
+					t.Errorf("[%d][%d]ArcsFromBezier(%v, %f) (arc sample) failed. %v > %v",
+						h, i, c, test.tolerance, d, deviation)
+				}
+			}
+		}
+	}
+
+	// Biarcs keep the tangents continuous.
+	arcs, _ := ArcsFromBezier(BezierPt(PtXy(0, 0), PtXy(1, 2), PtXy(3, 2), PtXy(4, 0)), 1e-2)
+	for i := 1; i < len(arcs); i++ {
+		a, _ := arcs[i-1].TangentAtT(1)
+		b, _ := arcs[i].TangentAtT(0)
+		if !IsEqualPair(a.Normalize(), b.Normalize()) {
+			t.Errorf("[%d]ArcsFromBezier() (tangent) failed. %v != %v", i, a.Normalize(), b.Normalize())
+		}
+	}
+
+	// A piece that can't be halved any more is kept, and the deviation
+	// reports that it is not within tolerance.
+	c := BezierPt(PtXy(0, 0), PtXy(1, 2), PtXy(3, 2), PtXy(4, 0))
+	arcs, deviation := appendBiarcs(nil, c, 1e-6, biarcMaxDepth)
+	if len(arcs) != 2 || !(deviation > 1e-6) {
+		t.Errorf("appendBiarcs() at the depth limit failed. %v, %v", arcs, deviation)
+	}
+	for i := 0; i <= 100; i++ {
+		p := c.PtAtT(float64(i) / 100)
+		best := Length(math.Inf(1))
+		for _, a := range arcs {
+			_, _, d := a.ClosestPt(p)
+			best = Minimum(best, d)
+		}
+		if best > deviation*(1+1e-6) {
+			t.Errorf("[%d]appendBiarcs() at the depth limit (sample) failed. %v > %v",
+				i, best, deviation)
+		}
+	}
+
+	if arcs, deviation := ArcsFromBezier(BezierPt(PtXy(0, 0), PtXy(1, 2), PtXy(3, 2), PtXy(4, 0)), -1); arcs != nil || !math.IsNaN(float64(deviation)) {
+		t.Errorf("ArcsFromBezier() with negative tolerance failed. %v, %v", arcs, deviation)
+	}
+}
@@ -0,0 +1,358 @@
+package figuring
+
+import (
+	"math"
+	"sort"
+)
+
+// BooleanOp is the type of boolean operation to perform between two shapes.
+type BooleanOp uint
+
+const (
+	BOOLEAN_OP_UNION BooleanOp = iota
+	BOOLEAN_OP_INTERSECTION
+	BOOLEAN_OP_DIFFERENCE
+	BOOLEAN_OP_XOR
+)
+
+// UnionPolygonPolygon returns the area covered by either \c a or \c b. See
+// \c BooleanPolygonPolygon for the format of the result.
+func UnionPolygonPolygon(a, b Polygon) []Polygon {
+	return BooleanPolygonPolygon(BOOLEAN_OP_UNION, a, b)
+}
+
+// IntersectionPolygonPolygon returns the area covered by both \c a and \c b.
+// See \c BooleanPolygonPolygon for the format of the result.
+func IntersectionPolygonPolygon(a, b Polygon) []Polygon {
+	return BooleanPolygonPolygon(BOOLEAN_OP_INTERSECTION, a, b)
+}
+
+// DifferencePolygonPolygon returns the area covered by \c a but not by \c b.
+// See \c BooleanPolygonPolygon for the format of the result.
+func DifferencePolygonPolygon(a, b Polygon) []Polygon {
+	return BooleanPolygonPolygon(BOOLEAN_OP_DIFFERENCE, a, b)
+}
+
+// XorPolygonPolygon returns the area covered by exactly one of \c a or \c b.
+// See \c BooleanPolygonPolygon for the format of the result.
+func XorPolygonPolygon(a, b Polygon) []Polygon {
+	return BooleanPolygonPolygon(BOOLEAN_OP_XOR, a, b)
+}
+
+// BooleanPolygonPolygon performs the boolean operation \c op between \c a
+// and \c b. Either polygon may be concave, and may touch itself at its
+// points, but sides are not expected to cross each other. The winding of
+// the inputs is ignored.
+//
+// The result is a set of rings. Counter-clockwise rings are the outer
+// boundaries of the result, and clockwise rings are holes in those outer
+// boundaries. Returns an empty slice when the result has no area. Use
+// \c MultiRegionFromRings to group the holes with their outer boundaries.
+func BooleanPolygonPolygon(op BooleanOp, a, b Polygon) []Polygon {
+	return booleanRings(op, ringsFromPolygon(a), ringsFromPolygon(b))
+}
+
+// ringsFromPolygon converts a polygon into a counter-clockwise ring. Polygons
+// without area have no rings.
+func ringsFromPolygon(poly Polygon) []Polygon {
+	if poly.Winding() == WINDING_NONE {
+		return nil
+	}
+	return []Polygon{poly.Normalize()}
+}
+
+// booleanEdge is a directed edge between two nodes of a booleanGraph. \c set
+// is 0 for edges from the first operand and 1 for the second operand.
+type booleanEdge struct {
+	from, to int
+	set      int
+}
+
+// key identifies the nodes of the edge, ignoring direction.
+func (e booleanEdge) key() [2]int {
+	if e.to < e.from {
+		return [2]int{e.to, e.from}
+	}
+	return [2]int{e.from, e.to}
+}
+
+func (e booleanEdge) reverse() booleanEdge {
+	return booleanEdge{from: e.to, to: e.from, set: e.set}
+}
+
+// booleanGraph holds the nodes shared by the edges of both operands. Points
+// within tolerance of each other are the same node.
+type booleanGraph struct {
+	nodes []Pt
+}
+
+func (g *booleanGraph) node(p Pt) int {
+	for h, n := range g.nodes {
+		if IsEqualPair(n, p) {
+			return h
+		}
+	}
+	g.nodes = append(g.nodes, p)
+	return len(g.nodes) - 1
+}
+
+// booleanRings performs \c op between two sets of rings. Counter-clockwise
+// rings are filled and clockwise rings are holes. The result uses the same
+// format.
+func booleanRings(op BooleanOp, a, b []Polygon) []Polygon {
+	rings := [2][]Polygon{a, b}
+	g, edges := splitRings(a, b)
+
+	// Keep the edges that border the result.
+	shared := sharedEdges(edges)
+	var keep []booleanEdge
+	for _, e := range edges {
+		var same, opposite bool
+		for _, i := range shared[e.key()] {
+			if edges[i].set != e.set {
+				same = same || edges[i].from == e.from
+				opposite = opposite || edges[i].from != e.from
+			}
+		}
+
+		switch {
+		case same:
+			if e.set == 0 && (op == BOOLEAN_OP_UNION || op == BOOLEAN_OP_INTERSECTION) {
+				keep = append(keep, e)
+			}
+		case opposite:
+			if e.set == 0 && op == BOOLEAN_OP_DIFFERENCE {
+				keep = append(keep, e)
+			}
+		default:
+			mid := g.midpoint(e)
+			inside := windingNumberRings(rings[1-e.set], mid) != 0
+
+			switch op {
+			case BOOLEAN_OP_UNION:
+				if !inside {
+					keep = append(keep, e)
+				}
+			case BOOLEAN_OP_INTERSECTION:
+				if inside {
+					keep = append(keep, e)
+				}
+			case BOOLEAN_OP_DIFFERENCE:
+				if e.set == 0 && !inside {
+					keep = append(keep, e)
+				} else if e.set == 1 && inside {
+					keep = append(keep, e.reverse())
+				}
+			case BOOLEAN_OP_XOR:
+				if inside {
+					keep = append(keep, e.reverse())
+				} else {
+					keep = append(keep, e)
+				}
+			}
+		}
+	}
+	return g.link(keep)
+}
+
+// resolveRings converts a set of rings, that may cross themselves and each
+// other, into rings that do not cross. The result uses the same format as
+// \c booleanRings, with \c rule deciding which areas are filled.
+func resolveRings(rings []Polygon, rule FillRule) []Polygon {
+	g, edges := splitRings(rings)
+
+	// Keep the edges that are filled on only one side. Edges that overlap
+	// are considered together.
+	done := make(map[[2]int]bool)
+	var keep []booleanEdge
+	for _, e := range edges {
+		if done[e.key()] {
+			continue
+		}
+		done[e.key()] = true
+
+		// Sample just to the left and right of the edge. Nodes closer than
+		// the tolerance were merged, so a sample within half the tolerance
+		// can't reach past another edge.
+		v := g.nodes[e.from].VectorTo(g.nodes[e.to]).Normalize()
+		vi, vj := v.Units()
+		left := VectorIj(-vj, vi).Scale(equalEpsilon / 2)
+		mid := g.midpoint(e)
+		l := rule.isFilled(windingNumberRings(rings, mid.Add(left)))
+		r := rule.isFilled(windingNumberRings(rings, mid.Add(left.Invert())))
+		switch {
+		case l && !r:
+			keep = append(keep, e)
+		case !l && r:
+			keep = append(keep, e.reverse())
+		}
+	}
+	return g.link(keep)
+}
+
+// windingNumberRings returns the sum of the winding numbers of \c rings
+// around \c p.
+func windingNumberRings(rings []Polygon, p Pt) int {
+	var wn int
+	for _, ring := range rings {
+		wn += ring.WindingNumber(p)
+	}
+	return wn
+}
+
+// splitRings breaks the sides of the rings into edges at every place a side
+// touches or crosses another side. The \c set of each edge is the index of
+// the ring set it came from.
+func splitRings(ringsets ...[]Polygon) (booleanGraph, []booleanEdge) {
+	var (
+		sides []Segment
+		sets  []int
+	)
+	for set, ringset := range ringsets {
+		for _, ring := range ringset {
+			for _, side := range ring.Sides() {
+				sides = append(sides, side)
+				sets = append(sets, set)
+			}
+		}
+	}
+
+	isInterior := func(p Pt, s Segment) bool {
+		return isPtOnSegment(p, s) && !IsEqualPair(p, s.b) && !IsEqualPair(p, s.e)
+	}
+	isEnd := func(p Pt, s Segment) bool {
+		return IsEqualPair(p, s.b) || IsEqualPair(p, s.e)
+	}
+	splits := make([][]Pt, len(sides))
+	for h := 0; h < len(sides); h++ {
+		for i := h + 1; i < len(sides); i++ {
+			e, f := sides[h], sides[i]
+			for _, p := range f.Points() {
+				if isInterior(p, e) {
+					splits[h] = append(splits[h], p)
+				}
+			}
+			for _, p := range e.Points() {
+				if isInterior(p, f) {
+					splits[i] = append(splits[i], p)
+				}
+			}
+			for _, p := range IntersectionSegmentSegment(e, f) {
+				if !isEnd(p, e) && !isEnd(p, f) {
+					splits[h] = append(splits[h], p)
+					splits[i] = append(splits[i], p)
+				}
+			}
+		}
+	}
+
+	var (
+		g     booleanGraph
+		edges []booleanEdge
+	)
+	for h, side := range sides {
+		pts := splits[h]
+		v := side.b.VectorTo(side.e)
+		sort.Slice(pts, func(i, j int) bool {
+			return side.b.VectorTo(pts[i]).Dot(v) < side.b.VectorTo(pts[j]).Dot(v)
+		})
+		prev := g.node(side.b)
+		for _, p := range append(pts, side.e) {
+			if n := g.node(p); n != prev {
+				edges = append(edges, booleanEdge{from: prev, to: n, set: sets[h]})
+				prev = n
+			}
+		}
+	}
+	return g, edges
+}
+
+// sharedEdges groups the indexes of the edges by the nodes they connect,
+// ignoring direction.
+func sharedEdges(edges []booleanEdge) map[[2]int][]int {
+	shared := make(map[[2]int][]int)
+	for h, e := range edges {
+		shared[e.key()] = append(shared[e.key()], h)
+	}
+	return shared
+}
+
+// link joins the edges into rings, always taking the left most turn so rings
+// that touch at a point are kept apart. Rings without area are dropped.
+func (g *booleanGraph) link(edges []booleanEdge) []Polygon {
+	out := make(map[int][]int)
+	for h, e := range edges {
+		out[e.from] = append(out[e.from], h)
+	}
+	used := make([]bool, len(edges))
+	var result []Polygon
+	for h := range edges {
+		if used[h] {
+			continue
+		}
+		used[h] = true
+		start := edges[h].from
+		pts := []Pt{g.nodes[start]}
+		closed := false
+		for curr := h; ; {
+			e := edges[curr]
+			if e.to == start {
+				closed = true
+				break
+			}
+			at := g.nodes[e.to]
+			pts = append(pts, at)
+			back := at.VectorTo(g.nodes[e.from]).Angle()
+			next, best := -1, Radians(0)
+			for _, i := range out[e.to] {
+				if used[i] {
+					continue
+				}
+				turn := (back - at.VectorTo(g.nodes[edges[i].to]).Angle()).Normalize()
+				if IsZero(turn) {
+					turn = 2 * math.Pi
+				}
+				if next < 0 || turn < best {
+					next, best = i, turn
+				}
+			}
+			if next < 0 {
+				break
+			}
+			used[next] = true
+			curr = next
+		}
+
+		if !closed {
+			continue
+		}
+		ring := PolygonPt(simplifyRing(pts)...)
+		if ring.Winding() != WINDING_NONE {
+			result = append(result, ring)
+		}
+	}
+	return result
+}
+
+// midpoint returns the point halfway along the edge.
+func (g *booleanGraph) midpoint(e booleanEdge) Pt {
+	b := g.nodes[e.from]
+	return b.Add(b.VectorTo(g.nodes[e.to]).Scale(Half))
+}
+
+// simplifyRing removes points that are duplicates of, or are collinear with,
+// their neighbors.
+func simplifyRing(pts []Pt) []Pt {
+	for changed := true; changed && len(pts) > 2; {
+		changed = false
+		for h := 0; h < len(pts) && len(pts) > 2; h++ {
+			prev, next := pts[(h+len(pts)-1)%len(pts)], pts[(h+1)%len(pts)]
+			if isPtOnSegment(pts[h], SegmentPt(prev, next)) {
+				pts = append(pts[:h], pts[h+1:]...)
+				changed = true
+				h--
+			}
+		}
+	}
+	return pts
+}
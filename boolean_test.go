@@ -0,0 +1,218 @@
+package figuring
+
+import (
+	"testing"
+)
+
+func TestBooleanPolygonPolygon(t *testing.T) {
+	square := PolygonPt(PtXy(0, 0), PtXy(4, 0), PtXy(4, 4), PtXy(0, 4))
+	shifted := PolygonPt(PtXy(2, 2), PtXy(6, 2), PtXy(6, 6), PtXy(2, 6))
+	inner := PolygonPt(PtXy(1, 1), PtXy(1, 3), PtXy(3, 3), PtXy(3, 1))
+	beside := PolygonPt(PtXy(4, 1), PtXy(8, 1), PtXy(8, 3), PtXy(4, 3))
+	corner := PolygonPt(PtXy(4, 4), PtXy(6, 4), PtXy(6, 6), PtXy(4, 6))
+	far := PolygonPt(PtXy(10, 10), PtXy(11, 10), PtXy(11, 11))
+	// U shape, open at the top.
+	cup := PolygonPt(PtXy(0, 0), PtXy(6, 0), PtXy(6, 6), PtXy(4, 6), PtXy(4, 2), PtXy(2, 2), PtXy(2, 6), PtXy(0, 6))
+	lid := PolygonPt(PtXy(-1, 4), PtXy(7, 4), PtXy(7, 5), PtXy(-1, 5))
+	// Two triangles touching at (1, 1).
+	hourglass := PolygonPt(PtXy(0, 0), PtXy(2, 0), PtXy(1, 1), PtXy(2, 2), PtXy(0, 2), PtXy(1, 1))
+
+	booleanTests := []struct {
+		op    BooleanOp
+		a, b  Polygon
+		outer int
+		holes int
+		area  Length
+	}{
+		{
+			//0
+			BOOLEAN_OP_UNION, square, shifted,
+			1, 0, 28,
+		}, {
+			BOOLEAN_OP_INTERSECTION, square, shifted,
+			1, 0, 4,
+		}, {
+			BOOLEAN_OP_DIFFERENCE, square, shifted,
+			1, 0, 12,
+		}, {
+			BOOLEAN_OP_XOR, square, shifted,
+			2, 0, 24,
+		}, {
+			BOOLEAN_OP_UNION, square, inner,
+			1, 0, 16,
+		}, {
+			//5
+			BOOLEAN_OP_INTERSECTION, square, inner,
+			1, 0, 4,
+		}, {
+			BOOLEAN_OP_DIFFERENCE, square, inner,
+			1, 1, 12,
+		}, {
+			BOOLEAN_OP_XOR, square, inner,
+			1, 1, 12,
+		}, {
+			BOOLEAN_OP_DIFFERENCE, inner, square,
+			0, 0, 0,
+		}, {
+			BOOLEAN_OP_UNION, square, beside,
+			1, 0, 24,
+		}, {
+			//10
+			BOOLEAN_OP_INTERSECTION, square, beside,
+			0, 0, 0,
+		}, {
+			BOOLEAN_OP_DIFFERENCE, square, beside,
+			1, 0, 16,
+		}, {
+			BOOLEAN_OP_UNION, square, corner,
+			2, 0, 20,
+		}, {
+			BOOLEAN_OP_UNION, square, far,
+			2, 0, 16.5,
+		}, {
+			BOOLEAN_OP_INTERSECTION, square, far,
+			0, 0, 0,
+		}, {
+			//15
+			BOOLEAN_OP_UNION, square, square,
+			1, 0, 16,
+		}, {
+			BOOLEAN_OP_INTERSECTION, square, square.Reverse(),
+			1, 0, 16,
+		}, {
+			BOOLEAN_OP_DIFFERENCE, square, square,
+			0, 0, 0,
+		}, {
+			BOOLEAN_OP_XOR, square, square,
+			0, 0, 0,
+		}, {
+			BOOLEAN_OP_INTERSECTION, cup, lid,
+			2, 0, 4,
+		}, {
+			//20
+			BOOLEAN_OP_UNION, cup, lid,
+			1, 1, 32,
+		}, {
+			BOOLEAN_OP_DIFFERENCE, cup, lid,
+			3, 0, 24,
+		}, {
+			BOOLEAN_OP_DIFFERENCE, lid, cup,
+			3, 0, 4,
+		}, {
+			BOOLEAN_OP_INTERSECTION, square, PolygonPt(PtXy(1, 1), PtXy(2, 2), PtXy(3, 3)),
+			0, 0, 0,
+		}, {
+			BOOLEAN_OP_UNION, hourglass, far,
+			3, 0, 2.5,
+		}, {
+			//25
+			BOOLEAN_OP_INTERSECTION, hourglass, square,
+			2, 0, 2,
+		}, {
+			BOOLEAN_OP_DIFFERENCE, square, hourglass,
+			2, 0, 14,
+		},
+	}
+	for h, test := range booleanTests {
+		op, a, b := test.op, test.a, test.b
+		rings := BooleanPolygonPolygon(op, a, b)
+		var (
+			outer, holes int
+			area         Length
+		)
+		for _, ring := range rings {
+			switch ring.Winding() {
+			case WINDING_COUNTERCLOCKWISE:
+				outer++
+			case WINDING_CLOCKWISE:
+				holes++
+			}
+			area += ring.SignedArea()
+		}
+		if outer != test.outer || holes != test.holes {
+			t.Errorf("[%d]BooleanPolygonPolygon(%d, %v, %v) (rings) failed. (%d, %d) != (%d, %d). %v",
+				h, op, a, b, outer, holes, test.outer, test.holes, rings)
+		}
+		if !IsEqual(area, test.area) {
+			t.Errorf("[%d]BooleanPolygonPolygon(%d, %v, %v) (area) failed. %f != %f. %v",
+				h, op, a, b, area, test.area, rings)
+		}
+	}
+
+	shapeTests := []struct {
+		a, b         Polygon
+		union, xsect []Polygon
+		diff, xor    []Polygon
+	}{
+		{
+			PolygonPt(PtXy(0, 0), PtXy(4, 0), PtXy(4, 4), PtXy(0, 4)),
+			PolygonPt(PtXy(2, 2), PtXy(6, 2), PtXy(6, 6), PtXy(2, 6)),
+			[]Polygon{PolygonPt(PtXy(0, 0), PtXy(4, 0), PtXy(4, 2), PtXy(6, 2), PtXy(6, 6), PtXy(2, 6), PtXy(2, 4), PtXy(0, 4))},
+			[]Polygon{PolygonPt(PtXy(4, 2), PtXy(4, 4), PtXy(2, 4), PtXy(2, 2))},
+			[]Polygon{PolygonPt(PtXy(0, 0), PtXy(4, 0), PtXy(4, 2), PtXy(2, 2), PtXy(2, 4), PtXy(0, 4))},
+			[]Polygon{
+				PolygonPt(PtXy(0, 0), PtXy(4, 0), PtXy(4, 2), PtXy(2, 2), PtXy(2, 4), PtXy(0, 4)),
+				PolygonPt(PtXy(4, 4), PtXy(4, 2), PtXy(6, 2), PtXy(6, 6), PtXy(2, 6), PtXy(2, 4)),
+			},
+		},
+	}
+	for h, test := range shapeTests {
+		a, b := test.a, test.b
+		funcs := []struct {
+			name     string
+			f        func(Polygon, Polygon) []Polygon
+			expected []Polygon
+		}{
+			{"UnionPolygonPolygon", UnionPolygonPolygon, test.union},
+			{"IntersectionPolygonPolygon", IntersectionPolygonPolygon, test.xsect},
+			{"DifferencePolygonPolygon", DifferencePolygonPolygon, test.diff},
+			{"XorPolygonPolygon", XorPolygonPolygon, test.xor},
+		}
+		for _, fn := range funcs {
+			rings := fn.f(a, b)
+			if len(rings) != len(fn.expected) {
+				t.Fatalf("[%d]%s(%v, %v) (length) failed. %v != %v",
+					h, fn.name, a, b, rings, fn.expected)
+			}
+			for i := 0; i < len(rings); i++ {
+				if !IsEqualPts(rings[i], fn.expected[i]) {
+					t.Errorf("[%d][%d]%s(%v, %v) failed. %v != %v",
+						h, i, fn.name, a, b, rings[i], fn.expected[i])
+				}
+			}
+		}
+	}
+}
+
+func TestResolveRings(t *testing.T) {
+	// Long sides close to each other.
+	bottom := PolygonPt(PtXy(-1e3, 0), PtXy(1e3, 0), PtXy(1e3, 1), PtXy(-1e3, 1))
+	top := PolygonPt(PtXy(-1e3, 1.001), PtXy(1e3, 1.001), PtXy(1e3, 2), PtXy(-1e3, 2))
+	// Short sides.
+	tiny := PolygonPt(PtXy(0, 0), PtXy(1e-3, 0), PtXy(1e-3, 1e-3), PtXy(0, 1e-3))
+
+	resolveTests := []struct {
+		rings []Polygon
+		count int
+		area  Length
+	}{
+		{[]Polygon{bottom, top}, 2, 2000 + 1998},
+		{[]Polygon{tiny}, 1, 1e-6},
+		{[]Polygon{bottom.Reverse()}, 0, 0},
+	}
+	for h, test := range resolveTests {
+		rings := resolveRings(test.rings, FILL_RULE_POSITIVE)
+		if len(rings) != test.count {
+			t.Errorf("[%d]resolveRings(%v) (length) failed. %d != %d",
+				h, test.rings, len(rings), test.count)
+		}
+		var area Length
+		for _, ring := range rings {
+			area += ring.Area()
+		}
+		if !IsEqual(area, test.area) {
+			t.Errorf("[%d]resolveRings(%v) (area) failed. %f != %f",
+				h, test.rings, area, test.area)
+		}
+	}
+}
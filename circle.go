@@ -1,6 +1,9 @@
 package figuring
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
 // Circle represents a geometric circle defined as a center point and a raidus.
 type Circle struct {
@@ -19,6 +22,102 @@ func CirclePt(c Pt, r Length) Circle {
 	}
 }
 
+// CircleFromPts creates the circle that passes through \c a, \c b, and \c c.
+// The circle is in error if the points are collinear.
+func CircleFromPts(a, b, c Pt) Circle {
+	// see https://en.wikipedia.org/wiki/Circumcircle#Cartesian_coordinates_2
+	ab, ac := a.VectorTo(b), a.VectorTo(c)
+	bx, by := ab.Units()
+	cx, cy := ac.Units()
+	d := 2 * (bx*cy - by*cx)
+	if IsZero(d) {
+		return CirclePt(PtNaN, Length(math.NaN()))
+	}
+	b2, c2 := bx*bx+by*by, cx*cx+cy*cy
+	center := VectorIj((cy*b2-by*c2)/d, (bx*c2-cx*b2)/d)
+	return CirclePt(a.Add(center), center.Magnitude())
+}
+
+// CircleCircumscribedPts creates the circle that passes through all three
+// points of the triangle \c a, \c b, \c c. Same as \c CircleFromPts.
+func CircleCircumscribedPts(a, b, c Pt) Circle { return CircleFromPts(a, b, c) }
+
+// CircleInscribedPts creates the largest circle that fits inside the triangle
+// \c a, \c b, \c c, touching all three sides. The circle is in error if the
+// points are collinear.
+func CircleInscribedPts(a, b, c Pt) Circle {
+	// see https://en.wikipedia.org/wiki/Incircle_and_excircles#Cartesian_coordinates
+	la, lb, lc := b.VectorTo(c).Magnitude(), c.VectorTo(a).Magnitude(), a.VectorTo(b).Magnitude()
+	perimeter := la + lb + lc
+	area := PolygonPt(a, b, c).Area()
+	if IsZero(area) || IsZero(perimeter) {
+		return CirclePt(PtNaN, Length(math.NaN()))
+	}
+	center := PtXy(
+		(la*a.X()+lb*b.X()+lc*c.X())/perimeter,
+		(la*a.Y()+lb*b.Y()+lc*c.Y())/perimeter,
+	)
+	return CirclePt(center, 2*area/perimeter)
+}
+
+// TangentsCircleCircle returns the segments that are tangent to both
+// circles. Each segment begins on \c a and ends on \c b. The outer tangents do
+// not pass between the circles, and the inner tangents cross between them.
+//
+// Circles that touch have a single tangent at the touching point, returned as
+// a zero length segment. Circles inside each other have no inner tangents,
+// and concentric circles have no tangents at all.
+func TangentsCircleCircle(a, b Circle) (outer, inner []Segment) {
+	// see https://en.wikipedia.org/wiki/Tangent_lines_to_circles#Tangent_lines_to_two_circles
+	v := a.c.VectorTo(b.c)
+	d := v.Magnitude()
+	if IsZero(d) {
+		return nil, nil
+	}
+	u := v.Normalize()
+
+	// The tangents touch each circle where the radius makes an angle of
+	// acos((ra -/+ rb)/d) with the line between the centers.
+	tangents := func(rb Length) []Segment {
+		cos := (a.r - rb) / d
+		if IsEqual(cos, 1) || IsEqual(cos, -1) {
+			n := u.Scale(Length(math.Copysign(1, float64(cos))))
+			p := a.c.Add(n.Scale(a.r))
+			return []Segment{SegmentPt(p, p)}
+		} else if cos > 1 || cos < -1 {
+			return nil
+		}
+		phi := Radians(math.Acos(float64(cos)))
+		segments := make([]Segment, 0, 2)
+		for _, theta := range []Radians{phi, -phi} {
+			n := u.Rotate(theta)
+			segments = append(segments, SegmentPt(a.c.Add(n.Scale(a.r)), b.c.Add(n.Scale(rb))))
+		}
+		return segments
+	}
+	return tangents(b.r), tangents(-b.r)
+}
+
+// AppendFlatten appends the points of \c Flatten to \c dst, and returns the
+// extended slice. It doesn't allocate when \c dst has enough capacity.
+func (c Circle) AppendFlatten(dst []Pt, tolerance Length) []Pt {
+	if _, err := c.OrErr(); err != nil || !(tolerance > 0) {
+		return dst
+	}
+	steps := arcFlattenSteps(c.r, 2*math.Pi, tolerance)
+	for h := 0; h < steps; h++ {
+		dst = append(dst, c.PtAtT(float64(h)/float64(steps)))
+	}
+	return append(dst, c.Begin())
+}
+
+// Area returns the area of the circle.
+func (c Circle) Area() Length { return Length(math.Pi) * c.r * c.r }
+
+// Begin returns the point at the start of the circle, at zero radians. See
+// \c PtAtT.
+func (c Circle) Begin() Pt { return c.PtAtTheta(0) }
+
 // BoundingBox returns the bounding box for the circle.
 func (c Circle) BoundingBox() Rectangle {
 	v := VectorIj(c.r, c.r)
@@ -26,6 +125,96 @@ func (c Circle) BoundingBox() Rectangle {
 	return RectanglePt(least, most)
 }
 
+// Center returns the center point of the circle.
+func (c Circle) Center() Pt { return c.c }
+
+// Circumference returns the distance around the circle.
+func (c Circle) Circumference() Length { return 2 * Length(math.Pi) * c.r }
+
+// ContainsPt returns if \c p is inside, outside, or on the boundary of the
+// circle.
+func (c Circle) ContainsPt(p Pt) Containment {
+	d := c.c.VectorTo(p).Magnitude()
+	switch {
+	case IsEqual(d, c.r):
+		return CONTAINMENT_BOUNDARY
+	case d < c.r:
+		return CONTAINMENT_INSIDE
+	}
+	return CONTAINMENT_OUTSIDE
+}
+
+// ClosestPt returns the value of \c t, the point, and the distance of the
+// point of the circle closest to \c p. See \c PtAtT. Every point of the
+// circle is closest to the center, so the center returns \c Begin.
+func (c Circle) ClosestPt(p Pt) (float64, Pt, Length) {
+	v := c.c.VectorTo(p)
+	d := v.Magnitude() - c.r
+	if d < 0 {
+		d = -d
+	}
+	if IsZero(v.Magnitude()) {
+		return 0, c.Begin(), d
+	}
+	theta := v.Angle()
+	return float64(theta) / (2 * math.Pi), c.PtAtTheta(theta), d
+}
+
+// CircumscribedPolygon returns a regular polygon with \c sides sides that
+// contains the circle, with each side touching the circle. The polygon is
+// counter-clockwise. Fewer than 3 sides results in an empty polygon.
+func (c Circle) CircumscribedPolygon(sides int) Polygon {
+	if sides < 3 {
+		return PolygonPt()
+	}
+	step := 2 * math.Pi / float64(sides)
+	r := c.r / Length(math.Cos(step/2))
+	pts := make([]Pt, sides)
+	for h := 0; h < sides; h++ {
+		v := VectorFromTheta(Radians(step * float64(h))).Scale(r)
+		pts[h] = c.c.Add(v)
+	}
+	return PolygonPt(pts...)
+}
+
+// End returns the point at the end of the circle, which is the same as
+// \c Begin.
+func (c Circle) End() Pt { return c.PtAtTheta(0) }
+
+// Flatten returns the points of a closed polyline that is no more than
+// \c tolerance from any point of the circle, starting and ending at \c Begin.
+// The chords are equal, and none spans more than a quarter of the circle. See
+// \c SegmentsFromPts. The tolerance must be greater than zero.
+func (c Circle) Flatten(tolerance Length) []Pt { return c.AppendFlatten(nil, tolerance) }
+
+// HullPts implements the HullPtser interface. The points are the corners of
+// a circumscribed polygon with enough sides that it is no more than
+// \c tolerance outside the circle. A circle with no radius is its center.
+// The tolerance must be greater than zero. Returns only \c PtNaN if the
+// tolerance or circle are in error, or the tolerance is lost in the rounding
+// of the radius, so \c ConvexHullPts reports the error.
+func (c Circle) HullPts(tolerance Length) []Pt {
+	if _, err := c.OrErr(); err != nil || !(tolerance > 0) {
+		return []Pt{PtNaN}
+	} else if IsZero(c.r) {
+		return []Pt{c.c}
+	}
+	// The corners of a regular polygon with n sides are r/cos(pi/n) from
+	// the center.
+	step := math.Acos(float64(c.r / (c.r + tolerance)))
+	if step == 0 {
+		return []Pt{PtNaN}
+	}
+	sides := int(math.Ceil(math.Pi / step))
+	if sides < 3 {
+		sides = 3
+	}
+	return c.CircumscribedPolygon(sides).Points()
+}
+
+// Length returns the distance around the circle. Same as \c Circumference.
+func (c Circle) Length() Length { return c.Circumference() }
+
 // OrErr returns a floating point error if either the center or the radius are
 // in error.
 func (c Circle) OrErr() (Circle, *FloatingPointError) {
@@ -49,6 +238,20 @@ func (c Circle) PtAtTheta(theta Radians) Pt {
 	return c.c.Add(v)
 }
 
+// PtAtLength returns the point \c length along the circle, counter-clockwise
+// from \c Begin.
+func (c Circle) PtAtLength(length Length) Pt { return c.PtAtT(c.TAtLength(length)) }
+
+// PtAtT returns the point on the circle for the provided value of \c t. The
+// circle is parameterized by arc length, with \c t from 0 to 1 going once
+// counter-clockwise around the circle from \c Begin.
+func (c Circle) PtAtT(t float64) Pt {
+	return c.PtAtTheta(Radians(2 * math.Pi * t))
+}
+
+// Radius returns the radius of the circle.
+func (c Circle) Radius() Length { return c.r }
+
 // String returns the implicit formula of this circle.
 func (c Circle) String() string {
 	x, y := c.c.XY()
@@ -71,3 +274,55 @@ func (c Circle) String() string {
 		HumanFormat(9, r),
 	)
 }
+
+// TAtLength returns the value of \c t that is \c length along the circle.
+// See \c PtAtT.
+func (c Circle) TAtLength(length Length) float64 {
+	return float64(length / c.Circumference())
+}
+
+// TangentAtT returns the tangent and the normal of the circle for the given
+// value of \c t. See \c PtAtT.
+func (c Circle) TangentAtT(t float64) (Vector, Vector) {
+	theta := Radians(2 * math.Pi * t)
+	speed := c.Circumference()
+	tangent := VectorFromTheta(theta + math.Pi/2).Scale(speed)
+	ti, tj := tangent.Units()
+	normal := VectorIj(-tj, ti)
+	return tangent, normal
+}
+
+// TangentLinesFromPt returns the lines through \c p that are tangent to the
+// circle. Points outside the circle have two tangent lines, points on the
+// circle have one, and points inside have none. See \c TangentPtsFromPt.
+func (c Circle) TangentLinesFromPt(p Pt) []Line {
+	pts := c.TangentPtsFromPt(p)
+	lines := make([]Line, 0, len(pts))
+	for _, tp := range pts {
+		if IsEqualPair(tp, p) {
+			// On the circle, the tangent is perpendicular to the radius.
+			ri, rj := c.c.VectorTo(p).Units()
+			lines = append(lines, LineFromVector(p, VectorIj(-rj, ri)))
+		} else {
+			lines = append(lines, LineFromPt(p, tp))
+		}
+	}
+	return lines
+}
+
+// TangentPtsFromPt returns the points on the circle where the lines through
+// \c p touch the circle. Returns nil for points inside the circle.
+func (c Circle) TangentPtsFromPt(p Pt) []Pt {
+	v := c.c.VectorTo(p)
+	d := v.Magnitude()
+	switch {
+	case IsEqual(d, c.r):
+		return []Pt{p}
+	case d < c.r:
+		return nil
+	}
+	// The radius to the tangent point is at acos(r/d) from the point.
+	phi := Radians(math.Acos(float64(c.r / d)))
+	u := v.Normalize().Scale(c.r)
+	return uniquePts([]Pt{c.c.Add(u.Rotate(phi)), c.c.Add(u.Rotate(-phi))})
+}
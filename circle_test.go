@@ -0,0 +1,253 @@
+package figuring
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCircle(t *testing.T) {
+	containsTests := []struct {
+		a Circle
+		p Pt
+		c Containment
+	}{
+		{CirclePt(PtOrig, 5), PtOrig, CONTAINMENT_INSIDE},
+		{CirclePt(PtOrig, 5), PtXy(3, 4), CONTAINMENT_BOUNDARY},
+		{CirclePt(PtOrig, 5), PtXy(0, -5.00000001), CONTAINMENT_BOUNDARY},
+		{CirclePt(PtXy(10, 10), 5), PtXy(3, 4), CONTAINMENT_OUTSIDE},
+		{CirclePt(PtXy(10, 10), 5), PtXy(12, 13), CONTAINMENT_INSIDE},
+		{CirclePt(PtXy(10, 10), 5), PtNaN, CONTAINMENT_OUTSIDE},
+	}
+	for h, test := range containsTests {
+		a := test.a
+		if c := a.ContainsPt(test.p); c != test.c {
+			t.Errorf("[%d](%s).ContainsPt(%v) failed. %d != %d",
+				h, a, test.p, c, test.c)
+		}
+	}
+
+	errorTests := []struct {
+		a     Circle
+		isErr bool
+	}{
+		{CirclePt(PtXy(1, 1), 5), false},
+		{CirclePt(PtXy(Length(math.NaN()), 1), 5), true},
+		{CirclePt(PtXy(1, 1), Length(math.Inf(1))), true},
+	}
+	for h, test := range errorTests {
+		a := test.a
+		_, err := a.OrErr()
+		if (err != nil) != test.isErr {
+			t.Errorf("[%d](%v).OrErr() failed. %t != %t. %v",
+				h, test.a, (err != nil), test.isErr, err)
+		}
+	}
+
+	constructorTests := []struct {
+		name string
+		a    Circle
+		c    Pt
+		r    Length
+	}{
+		{
+			//0
+			"CircleFromPts", CircleFromPts(PtXy(1, 0), PtXy(0, 1), PtXy(-1, 0)),
+			PtXy(0, 0), 1,
+		}, {
+			"CircleFromPts", CircleFromPts(PtXy(0, 0), PtXy(6, 0), PtXy(0, 8)),
+			PtXy(3, 4), 5,
+		}, {
+			"CircleCircumscribedPts", CircleCircumscribedPts(PtXy(8, 4), PtXy(2, 12), PtXy(2, 4)),
+			PtXy(5, 8), 5,
+		}, {
+			"CircleInscribedPts", CircleInscribedPts(PtXy(0, 0), PtXy(3, 0), PtXy(0, 4)),
+			PtXy(1, 1), 1,
+		}, {
+			"CircleInscribedPts", CircleInscribedPts(PtXy(2, 2), PtXy(2, 8), PtXy(10, 2)),
+			PtXy(4, 4), 2,
+		},
+	}
+	for h, test := range constructorTests {
+		a := test.a
+		if c := a.Center(); !IsEqualPair(c, test.c) {
+			t.Errorf("[%d]%s().Center() failed. %v != %v",
+				h, test.name, c, test.c)
+		}
+		if r := a.Radius(); !IsEqual(r, test.r) {
+			t.Errorf("[%d]%s().Radius() failed. %f != %f",
+				h, test.name, r, test.r)
+		}
+	}
+	for h, a := range []Circle{
+		CircleFromPts(PtXy(0, 0), PtXy(1, 1), PtXy(2, 2)),
+		CircleInscribedPts(PtXy(0, 0), PtXy(1, 1), PtXy(2, 2)),
+	} {
+		if _, err := a.OrErr(); err == nil {
+			t.Errorf("[%d](%v).OrErr() failed. collinear points should be in error",
+				h, a)
+		}
+	}
+
+	measureTests := []struct {
+		a            Circle
+		area, length Length
+		t            float64
+		pt           Pt
+		tangent      Vector
+	}{
+		{
+			//0
+			CirclePt(PtXy(1, 1), 1), Length(math.Pi), 2 * Length(math.Pi),
+			0.25, PtXy(1, 2), VectorIj(-2*math.Pi, 0),
+		}, {
+			CirclePt(PtXy(0, 0), 2), 4 * Length(math.Pi), 4 * Length(math.Pi),
+			0, PtXy(2, 0), VectorIj(0, 4*math.Pi),
+		},
+	}
+	for h, test := range measureTests {
+		a := test.a
+		if area := a.Area(); !IsEqual(area, test.area) {
+			t.Errorf("[%d](%v).Area() failed. %f != %f",
+				h, a, area, test.area)
+		}
+		if length := a.Circumference(); !IsEqual(length, test.length) {
+			t.Errorf("[%d](%v).Circumference() failed. %f != %f",
+				h, a, length, test.length)
+		}
+		if p := a.PtAtT(test.t); !IsEqualPair(p, test.pt) {
+			t.Errorf("[%d](%v).PtAtT(%f) failed. %v != %v",
+				h, a, test.t, p, test.pt)
+		}
+		length := test.length * Length(test.t)
+		if p := a.PtAtLength(length); !IsEqualPair(p, test.pt) {
+			t.Errorf("[%d](%v).PtAtLength(%f) failed. %v != %v",
+				h, a, length, p, test.pt)
+		}
+		if tangent, _ := a.TangentAtT(test.t); !IsEqualPair(tangent, test.tangent) {
+			t.Errorf("[%d](%v).TangentAtT(%f) failed. %v != %v",
+				h, a, test.t, tangent, test.tangent)
+		}
+	}
+
+	tangentTests := []struct {
+		a   Circle
+		p   Pt
+		pts []Pt
+	}{
+		{
+			//0
+			CirclePt(PtOrig, 1), PtXy(2, 0),
+			[]Pt{PtXy(0.5, -0.866025403784), PtXy(0.5, 0.866025403784)},
+		}, {
+			CirclePt(PtOrig, 5), PtXy(3, 4),
+			[]Pt{PtXy(3, 4)},
+		}, {
+			CirclePt(PtOrig, 5), PtXy(1, 1),
+			nil,
+		},
+	}
+	for h, test := range tangentTests {
+		a := test.a
+		pts := a.TangentPtsFromPt(test.p)
+		if !IsEqualPts(PolygonPt(pts...), PolygonPt(test.pts...)) {
+			t.Errorf("[%d](%v).TangentPtsFromPt(%v) failed. %v != %v",
+				h, a, test.p, pts, test.pts)
+		}
+		lines := a.TangentLinesFromPt(test.p)
+		if len(lines) != len(test.pts) {
+			t.Errorf("[%d](%v).TangentLinesFromPt(%v) (length) failed. %d != %d",
+				h, a, test.p, len(lines), len(test.pts))
+			continue
+		}
+		for i, line := range lines {
+			if xsect := IntersectionCircleLine(a, line); len(xsect) != 1 {
+				t.Errorf("[%d][%d](%v).TangentLinesFromPt(%v) failed. %v is not tangent",
+					h, i, a, test.p, line)
+			}
+		}
+	}
+
+	commonTests := []struct {
+		a, b         Circle
+		outer, inner []Segment
+	}{
+		{
+			//0
+			CirclePt(PtXy(0, 0), 1), CirclePt(PtXy(4, 0), 1),
+			[]Segment{
+				SegmentPt(PtXy(0, 1), PtXy(4, 1)),
+				SegmentPt(PtXy(0, -1), PtXy(4, -1)),
+			},
+			[]Segment{
+				SegmentPt(PtXy(0.5, 0.866025403784), PtXy(3.5, -0.866025403784)),
+				SegmentPt(PtXy(0.5, -0.866025403784), PtXy(3.5, 0.866025403784)),
+			},
+		}, {
+			CirclePt(PtXy(0, 0), 1), CirclePt(PtXy(2, 0), 1),
+			[]Segment{
+				SegmentPt(PtXy(0, 1), PtXy(2, 1)),
+				SegmentPt(PtXy(0, -1), PtXy(2, -1)),
+			},
+			[]Segment{SegmentPt(PtXy(1, 0), PtXy(1, 0))},
+		}, {
+			CirclePt(PtXy(0, 0), 2), CirclePt(PtXy(1, 0), 1),
+			[]Segment{SegmentPt(PtXy(2, 0), PtXy(2, 0))},
+			nil,
+		}, {
+			CirclePt(PtXy(0, 0), 3), CirclePt(PtXy(1, 0), 1),
+			nil,
+			nil,
+		}, {
+			CirclePt(PtXy(0, 0), 3), CirclePt(PtXy(0, 0), 1),
+			nil,
+			nil,
+		},
+	}
+	for h, test := range commonTests {
+		a, b := test.a, test.b
+		outer, inner := TangentsCircleCircle(a, b)
+		for _, pair := range []struct {
+			name          string
+			got, expected []Segment
+		}{{"outer", outer, test.outer}, {"inner", inner, test.inner}} {
+			if len(pair.got) != len(pair.expected) {
+				t.Errorf("[%d]TangentsCircleCircle(%v, %v) (%s length) failed. %v != %v",
+					h, a, b, pair.name, pair.got, pair.expected)
+				continue
+			}
+			for i := range pair.got {
+				if !IsEqualPts(pair.got[i], pair.expected[i]) {
+					t.Errorf("[%d][%d]TangentsCircleCircle(%v, %v) (%s) failed. %v != %v",
+						h, i, a, b, pair.name, pair.got[i], pair.expected[i])
+				}
+			}
+		}
+	}
+
+	closestTests := []struct {
+		a    Circle
+		p    Pt
+		t    float64
+		pt   Pt
+		dist Length
+	}{
+		{
+			//0
+			CirclePt(PtXy(1, 1), 2), PtXy(1, 5),
+			0.25, PtXy(1, 3), 2,
+		}, {
+			CirclePt(PtXy(1, 1), 2), PtXy(2, 1),
+			0, PtXy(3, 1), 1,
+		}, {
+			CirclePt(PtXy(1, 1), 2), PtXy(1, 1),
+			0, PtXy(3, 1), 2,
+		},
+	}
+	for h, test := range closestTests {
+		tv, pt, dist := test.a.ClosestPt(test.p)
+		if !IsEqual(tv, test.t) || !IsEqualPair(pt, test.pt) || !IsEqual(dist, test.dist) {
+			t.Errorf("[%d](%v).ClosestPt(%v) failed. %f, %v, %f != %f, %v, %f",
+				h, test.a, test.p, tv, pt, dist, test.t, test.pt, test.dist)
+		}
+	}
+}
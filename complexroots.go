@@ -0,0 +1,226 @@
+package figuring
+
+import (
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+// ComplexRooter represents a polynomial that can find all of its roots,
+// including the complex ones.
+type ComplexRooter interface {
+	Polynomial
+	// ComplexRoots returns every root, repeated by multiplicity, so a
+	// polynomial of degree n has n roots. See \c SortComplexRoots for the
+	// order.
+	ComplexRoots() []complex128
+}
+
+// SortComplexRoots sorts \c roots in place, from the least real part to the
+// greatest, then from the least imaginary part to the greatest. Conjugate
+// pairs are next to each other, with the negative imaginary part first.
+func SortComplexRoots(roots []complex128) {
+	sort.Slice(roots, func(i, j int) bool {
+		if real(roots[i]) != real(roots[j]) {
+			return real(roots[i]) < real(roots[j])
+		}
+		return imag(roots[i]) < imag(roots[j])
+	})
+}
+
+// ComplexRoots implements the ComplexRooter interface. A constant has no
+// roots.
+func (co Constant) ComplexRoots() []complex128 { return nil }
+
+// ComplexRoots implements the ComplexRooter interface.
+func (le Linear) ComplexRoots() []complex128 {
+	var roots []complex128
+	for _, root := range le.Roots() {
+		roots = append(roots, complex(root, 0))
+	}
+	return roots
+}
+
+// ComplexRoots implements the ComplexRooter interface. Repeated roots are
+// returned twice. A quadratic only has fewer than two roots when \c a is
+// zero.
+//
+// The discriminant is treated as zero when it is lost in the rounding of its
+// terms, and the real roots use the form that avoids cancellation, so small
+// roots next to large ones stay accurate.
+func (qad Quadratic) ComplexRoots() []complex128 {
+	// see https://en.wikipedia.org/wiki/Quadratic_formula#Numerical_calculation
+	a, b, c := qad.Abc()
+	if a == 0 {
+		return LinearAb(b, c).ComplexRoots()
+	}
+	D := b*b - 4*a*c
+	if math.Abs(D) <= rootTolerance*(b*b+math.Abs(4*a*c)) {
+		f := -b / (2 * a)
+		return []complex128{complex(f, 0), complex(f, 0)}
+	} else if D < 0 {
+		f := -b / (2 * a)
+		g := math.Sqrt(-D) / math.Abs(2*a)
+		return []complex128{complex(f, -g), complex(f, g)}
+	}
+	q := -(b + math.Copysign(math.Sqrt(D), b)) / 2
+	r1, r2 := q/a, c/q
+	if r2 < r1 {
+		r1, r2 = r2, r1
+	}
+	return []complex128{complex(r1, 0), complex(r2, 0)}
+}
+
+// ComplexRoots implements the ComplexRooter interface. See
+// \c PolynomialN.ComplexRoots.
+func (cub Cubic) ComplexRoots() []complex128 {
+	return PolynomialNFrom(cub).ComplexRoots()
+}
+
+// ComplexRoots implements the ComplexRooter interface. See
+// \c PolynomialN.ComplexRoots.
+func (qrt Quartic) ComplexRoots() []complex128 {
+	return PolynomialNFrom(qrt).ComplexRoots()
+}
+
+// ComplexRoots implements the ComplexRooter interface.
+//
+// The real roots come from \c RealRoots and are divided out as many times
+// as they are repeated. Each square-free factor of what remains only has
+// conjugate pairs, which are found with the Aberth method and polished with
+// Newton's method against the polynomial. The zero polynomial has no roots.
+func (p PolynomialN) ComplexRoots() []complex128 {
+	n := p.Degree()
+	if n < 1 {
+		return nil
+	}
+	var bound float64
+	for _, c := range p.terms()[1:] {
+		bound = math.Max(bound, math.Abs(c/p.terms()[0]))
+	}
+
+	var reals []complex128
+	deflated := p
+	for _, root := range p.RealRoots(-1-bound, 1+bound) {
+		for i := 0; i < root.Multiplicity; i++ {
+			reals = append(reals, complex(root.T, 0))
+			deflated, _ = deflated.Divide(PolynomialNCoefficients(1, -root.T))
+		}
+	}
+	roots := append(make([]complex128, 0, n), reals...)
+	if deflated.Degree() >= 2 {
+		for h, factor := range deflated.squareFree() {
+			for _, root := range factor.pairRoots(p) {
+				for i := 0; i <= h; i++ {
+					roots = append(roots, root)
+				}
+			}
+		}
+	}
+
+	// A real root missed by RealRoots, or a factor that isn't made of
+	// pairs, loses roots. Solve what remains without assuming pairs.
+	if len(roots) != n {
+		roots = append(roots[:0], reals...)
+		if deflated.Degree() >= 1 {
+			for _, z := range deflated.aberth() {
+				roots = append(roots, p.polishComplexRoot(z))
+			}
+		}
+	}
+	SortComplexRoots(roots)
+	return roots
+}
+
+// pairRoots returns the roots of a polynomial without repeated or real
+// roots, which are conjugate pairs. The roots are polished against \c orig,
+// the polynomial the factor came from.
+func (p PolynomialN) pairRoots(orig PolynomialN) []complex128 {
+	if p.Degree() < 2 {
+		return nil
+	}
+	// Keep the upper half and mirror it, so each pair is exact.
+	pairs := p.aberth()
+	sort.Slice(pairs, func(i, j int) bool { return imag(pairs[i]) > imag(pairs[j]) })
+	var roots []complex128
+	for _, z := range pairs[:len(pairs)/2] {
+		z = orig.polishComplexRoot(complex(real(z), math.Abs(imag(z))))
+		roots = append(roots, z, cmplx.Conj(z))
+	}
+	return roots
+}
+
+// aberth returns approximations of every root of the polynomial, using the
+// Aberth method.
+func (p PolynomialN) aberth() []complex128 {
+	// see https://en.wikipedia.org/wiki/Aberth_method
+	n := p.Degree()
+	coeffs := p.terms()
+	var bound float64
+	for _, c := range coeffs[1:] {
+		bound = math.Max(bound, math.Abs(c/coeffs[0]))
+	}
+	center := -coeffs[1] / (float64(n) * coeffs[0])
+
+	// Start on a circle around the center of the roots. The offset angle
+	// keeps the guesses off the real axis.
+	zs := make([]complex128, n)
+	for h := range zs {
+		zs[h] = complex(center, 0) + cmplx.Rect(1+bound, 2*math.Pi*float64(h)/float64(n)+0.4)
+	}
+
+	deriv := p.FirstDerivative()
+	for iter := 0; iter < rootIterations; iter++ {
+		converged := true
+		for h, z := range zs {
+			pz, dz := p.complexAtT(z), deriv.complexAtT(z)
+			if pz == 0 {
+				continue
+			}
+			w := pz / dz
+			var s complex128
+			for i, zi := range zs {
+				if i != h {
+					s += 1 / (z - zi)
+				}
+			}
+			step := w / (1 - w*s)
+			if cmplx.IsNaN(step) || cmplx.IsInf(step) {
+				continue
+			}
+			zs[h] = z - step
+			if cmplx.Abs(step) > 1e-15*math.Max(1, cmplx.Abs(z)) {
+				converged = false
+			}
+		}
+		if converged {
+			break
+		}
+	}
+	return zs
+}
+
+// polishComplexRoot returns \c z after a few steps of Newton's method. The
+// steps stop if they make the polynomial larger.
+func (p PolynomialN) polishComplexRoot(z complex128) complex128 {
+	deriv := p.FirstDerivative()
+	pz := p.complexAtT(z)
+	for iter := 0; iter < 8 && pz != 0; iter++ {
+		next := z - pz/deriv.complexAtT(z)
+		pn := p.complexAtT(next)
+		if cmplx.IsNaN(next) || cmplx.Abs(pn) >= cmplx.Abs(pz) {
+			break
+		}
+		z, pz = next, pn
+	}
+	return z
+}
+
+// complexAtT evaluates the polynomial for the provided complex t value.
+func (p PolynomialN) complexAtT(t complex128) complex128 {
+	var sum complex128
+	for _, c := range p.terms() {
+		sum = sum*t + complex(c, 0)
+	}
+	return sum
+}
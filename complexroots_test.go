@@ -0,0 +1,133 @@
+package figuring
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestComplexRoots(t *testing.T) {
+	pn := PolynomialNCoefficients
+	tests := []struct {
+		eq    ComplexRooter
+		roots []complex128
+	}{
+		{
+			//0
+			ConstantA(4),
+			nil,
+		}, {
+			LinearAb(2, -3),
+			[]complex128{1.5},
+		}, {
+			// (t-1)(t+2)
+			QuadraticAbc(1, 1, -2),
+			[]complex128{-2, 1},
+		}, {
+			// (t-1)^2
+			QuadraticAbc(1, -2, 1),
+			[]complex128{1, 1},
+		}, {
+			// t^2+2t+5
+			QuadraticAbc(1, 2, 5),
+			[]complex128{complex(-1, -2), complex(-1, 2)},
+		}, {
+			//5
+			// (t-2)(t^2+1)
+			CubicAbcd(1, -2, 1, -2),
+			[]complex128{complex(0, -1), complex(0, 1), 2},
+		}, {
+			// (t-1)^3
+			CubicAbcd(1, -3, 3, -1),
+			[]complex128{1, 1, 1},
+		}, {
+			// (t^2+1)(t^2+4)
+			QuarticAbcde(1, 0, 5, 0, 4),
+			[]complex128{complex(0, -2), complex(0, -1), complex(0, 1), complex(0, 2)},
+		}, {
+			// (t^2-2t+2)^2
+			QuarticAbcde(1, -4, 8, -8, 4),
+			[]complex128{complex(1, -1), complex(1, -1), complex(1, 1), complex(1, 1)},
+		}, {
+			// (t+3)(t-1)(t^2+t+1)
+			QuarticAbcde(1, 3, 0, -1, -3),
+			[]complex128{-3, complex(-0.5, -0.866025403784), complex(-0.5, 0.866025403784), 1},
+		}, {
+			//10
+			// (t-1)(t+1)(t^2+9)(t-0.5)^2
+			pn(1, -1).Mul(pn(1, 1)).Mul(pn(1, 0, 9)).Mul(pn(1, -0.5)).Mul(pn(1, -0.5)),
+			[]complex128{-1, complex(0, -3), complex(0, 3), 0.5, 0.5, 1},
+		}, {
+			// t^5-1
+			pn(1, 0, 0, 0, 0, -1),
+			[]complex128{
+				complex(-0.809016994375, -0.587785252292), complex(-0.809016994375, 0.587785252292),
+				complex(0.309016994375, -0.951056516295), complex(0.309016994375, 0.951056516295),
+				1,
+			},
+		}, {
+			pn(7),
+			nil,
+		}, {
+			// Small roots are not lost in the rounding.
+			QuadraticAbc(1, 0, -1e-10),
+			[]complex128{-1e-5, 1e-5},
+		}, {
+			QuadraticAbc(1e-10, 1, 1),
+			[]complex128{-1e10, -1},
+		}, {
+			//15
+			QuadraticAbc(1, 1e8, 1),
+			[]complex128{-1e8, -1e-8},
+		}, {
+			QuadraticAbc(1, 0, 1e-10),
+			[]complex128{complex(0, -1e-5), complex(0, 1e-5)},
+		}, {
+			// Nearly repeated roots are not merged.
+			pn(1, -0.5).Mul(pn(1, -0.50001)),
+			[]complex128{0.5, 0.50001},
+		}, {
+			pn(1, -0.2).Mul(pn(1, -0.2)).Mul(pn(1, -0.5)).Mul(pn(1, -0.50001)),
+			[]complex128{0.2, 0.2, 0.5, 0.50001},
+		}, {
+			// A repeated pair times a real root.
+			// (t^2+1)^2(t-1)
+			pn(1, 0, 1).Mul(pn(1, 0, 1)).Mul(pn(1, -1)),
+			[]complex128{complex(0, -1), complex(0, -1), complex(0, 1), complex(0, 1), 1},
+		}, {
+			//20
+			// (t^2+2t+5)^2(t+3)
+			pn(1, 2, 5).Mul(pn(1, 2, 5)).Mul(pn(1, 3)),
+			[]complex128{-3, complex(-1, -2), complex(-1, -2), complex(-1, 2), complex(-1, 2)},
+		},
+	}
+	for h, test := range tests {
+		roots := test.eq.ComplexRoots()
+		if len(roots) != len(test.roots) {
+			t.Errorf("[%d](%v).ComplexRoots() length failed. %v != %v",
+				h, test.eq, roots, test.roots)
+			continue
+		}
+		for i := range roots {
+			if !IsEqual(real(roots[i]), real(test.roots[i])) || !IsEqual(imag(roots[i]), imag(test.roots[i])) {
+				t.Errorf("[%d][%d](%v).ComplexRoots() failed. %v != %v",
+					h, i, test.eq, roots[i], test.roots[i])
+			}
+		}
+		// Every root is a root of the equation, relative to the size of
+		// its terms.
+		if eq, ok := test.eq.(Coefficienter); ok {
+			p := PolynomialNFrom(eq)
+			for i, root := range roots {
+				var size float64
+				for _, c := range p.terms() {
+					size = size*cmplx.Abs(root) + math.Abs(c)
+				}
+				if v := p.complexAtT(root); !IsZero(cmplx.Abs(v) / math.Max(1, size)) {
+					t.Errorf("[%d][%d](%v).ComplexRoots() (residual) failed. %v != 0",
+						h, i, test.eq, v)
+				}
+			}
+		}
+	}
+}
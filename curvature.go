@@ -0,0 +1,142 @@
+package figuring
+
+import (
+	"math"
+)
+
+// curvatureDerivatives returns the first and second derivatives of the
+// curve's functions. Returns false if a derivative can not be derived.
+func curvatureDerivatives(x, y Derivable) (x1, y1, x2, y2 Derivable, ok bool) {
+	if x1, ok = x.Derivative().(Derivable); !ok {
+		return
+	}
+	if y1, ok = y.Derivative().(Derivable); !ok {
+		return
+	}
+	if x2, ok = x1.Derivative().(Derivable); !ok {
+		return
+	}
+	y2, ok = y1.Derivative().(Derivable)
+	return
+}
+
+// curvatureAtT returns the signed curvature of the curve at \c t. Positive
+// curvature turns counter-clockwise (left), and negative curvature turns
+// clockwise (right). Returns NaN where the curve has no direction, such as
+// a cusp.
+func curvatureAtT(x, y Derivable, t float64) float64 {
+	// see https://pomax.github.io/bezierinfo/#curvature
+	x1, y1, x2, y2, ok := curvatureDerivatives(x, y)
+	if !ok {
+		return math.NaN()
+	}
+	dx, dy := x1.AtT(t), y1.AtT(t)
+	ddx, ddy := x2.AtT(t), y2.AtT(t)
+	speed2 := dx*dx + dy*dy
+	if speed2 == 0 {
+		return math.NaN()
+	}
+	return (dx*ddy - dy*ddx) / math.Pow(speed2, 1.5)
+}
+
+// radiusOfCurvature returns the radius of the osculating circle for the
+// curvature \c k. Straight parts of a curve have an infinite radius.
+func radiusOfCurvature(k float64) Length {
+	if k == 0 {
+		return Length(math.Inf(1))
+	}
+	return Length(math.Abs(1 / k))
+}
+
+// osculatingCircle returns the circle that touches the curve at \c p, with
+// the direction \c tangent and curvature \c k. The circle is in error where
+// the curve is straight, or has no direction.
+func osculatingCircle(p Pt, tangent Vector, k float64) Circle {
+	if k == 0 || math.IsNaN(k) || IsZero(tangent.Magnitude()) {
+		return CirclePt(PtNaN, Length(math.Inf(1)))
+	}
+	ti, tj := tangent.Normalize().Units()
+	// The center is on the left of the tangent for positive curvature.
+	center := p.Add(VectorIj(-tj, ti).Scale(Length(1 / k)))
+	return CirclePt(center, radiusOfCurvature(k))
+}
+
+// maxCurvatureTs returns the values of \c t between \c lo and \c hi where the
+// magnitude of the curvature has a local maximum. Cusps, where the curvature
+// is unbounded, are included. Straight curves have no maximum.
+func maxCurvatureTs(x, y Derivable, lo, hi float64) []float64 {
+	x1, y1, x2, y2, ok := curvatureDerivatives(x, y)
+	if !ok {
+		return nil
+	}
+	var candidates []float64
+	coeffs, ok := curvatureExtremaCoefficients(x1, y1, x2, y2)
+	if ok {
+		candidates = polynomialRoots(coeffs, lo, hi)
+	} else {
+		// Without coefficients, use the samples where the curvature is
+		// larger than its neighbors.
+		const samples = 64
+		step := (hi - lo) / samples
+		prev, curr := math.Inf(-1), math.Abs(curvatureAtT(x, y, lo))
+		for h := 1; h <= samples; h++ {
+			next := math.Abs(curvatureAtT(x, y, lo+step*float64(h)))
+			if curr > prev && curr >= next {
+				candidates = append(candidates, lo+step*float64(h-1))
+			}
+			prev, curr = curr, next
+		}
+	}
+	candidates = append(append([]float64{lo}, candidates...), hi)
+
+	absK := func(t float64) float64 {
+		k := math.Abs(curvatureAtT(x, y, Clamp(lo, t, hi)))
+		if math.IsNaN(k) {
+			return math.Inf(1)
+		}
+		return k
+	}
+	delta := (hi - lo) * 1e-6
+	var ts []float64
+	for _, t := range candidates {
+		k := absK(t)
+		if k == 0 || IsZero(k) {
+			continue
+		}
+		if k >= absK(t-delta) && k >= absK(t+delta) {
+			if len(ts) == 0 || !IsZero(ts[len(ts)-1]-t) {
+				ts = append(ts, t)
+			}
+		}
+	}
+	return ts
+}
+
+// curvatureExtremaCoefficients returns the coefficients of a polynomial that
+// is zero where the curvature has an extreme. Returns false if the
+// derivatives do not provide coefficients.
+func curvatureExtremaCoefficients(x1, y1, x2, y2 Derivable) ([]float64, bool) {
+	// The curvature is k = C / S^(3/2), with C = x'y'' - y'x'' and
+	// S = x'^2 + y'^2. The extremes of k^2 are where
+	// 2 C' S - 3 C S' = 0, with C' = x'y''' - y'x'''.
+	x3, y3 := x2.Derivative(), y2.Derivative()
+	var cs [6][]float64
+	for h, p := range []Polynomial{x1, y1, x2, y2, x3, y3} {
+		c, ok := p.(Coefficienter)
+		if !ok {
+			return nil, false
+		}
+		cs[h] = c.Coefficients()
+	}
+	dx, dy, ddx, ddy, dddx, dddy := cs[0], cs[1], cs[2], cs[3], cs[4], cs[5]
+	neg := func(p []float64) []float64 { return polynomialMul([]float64{-1}, p) }
+
+	c := polynomialAdd(polynomialMul(dx, ddy), neg(polynomialMul(dy, ddx)))
+	c1 := polynomialAdd(polynomialMul(dx, dddy), neg(polynomialMul(dy, dddx)))
+	s := polynomialAdd(polynomialMul(dx, dx), polynomialMul(dy, dy))
+	s1 := polynomialMul([]float64{2}, polynomialAdd(polynomialMul(dx, ddx), polynomialMul(dy, ddy)))
+	return polynomialAdd(
+		polynomialMul([]float64{2}, polynomialMul(c1, s)),
+		polynomialMul([]float64{-3}, polynomialMul(c, s1)),
+	), true
+}
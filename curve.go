@@ -28,6 +28,10 @@ var (
 	}
 )
 
+// bezierReduceMaxDepth limits how many times a curve is halved while
+// reducing it to cubic curves.
+const bezierReduceMaxDepth = 16
+
 // deCasteljau Performs de Casteljau to return all the subpoints for the
 // derivitive of points represented by the bezierCurve.
 //
@@ -62,6 +66,195 @@ func DeCasteljauSplit(s []Pt, tf float64) ([]Pt, []Pt) {
 	return left, right
 }
 
+// ElevatePts returns the control points of the same curve as \c pts, raised
+// to \c degree. A curve with n points has degree n-1, so raising a quadratic
+// to a cubic turns 3 points into 4. The curve is unchanged. Points are
+// returned unchanged if the curve is already at or above \c degree.
+func ElevatePts(pts []Pt, degree int) []Pt {
+	// see https://pomax.github.io/bezierinfo/#reordering
+	ret := make([]Pt, len(pts))
+	copy(ret, pts)
+	for len(ret) > 0 && len(ret)-1 < degree {
+		n := Length(len(ret))
+		next := make([]Pt, len(ret)+1)
+		next[0], next[len(ret)] = ret[0], ret[len(ret)-1]
+		for h := 1; h < len(ret); h++ {
+			k := Length(h) / n
+			next[h] = PtXy(
+				k*ret[h-1].X()+(1-k)*ret[h].X(),
+				k*ret[h-1].Y()+(1-k)*ret[h].Y(),
+			)
+		}
+		ret = next
+	}
+	return ret
+}
+
+// ReducePts returns the control points of a curve of \c degree that
+// approximates the curve of \c pts. The first and last points are kept, and
+// the other points are a least squares fit of the original control points.
+// Returns the points and a bound on the distance between the two curves:
+// no point of the original curve is further than the bound from the point
+// with the same \c t on the reduced curve.
+//
+// Points are returned unchanged, with a bound of zero, if the curve is
+// already at or below \c degree. Degrees less than 1 are treated as 1.
+func ReducePts(pts []Pt, degree int) ([]Pt, Length) {
+	// see https://pomax.github.io/bezierinfo/#reordering
+	if degree < 1 {
+		degree = 1
+	}
+	n := len(pts) - 1
+	if n <= degree {
+		ret := make([]Pt, len(pts))
+		copy(ret, pts)
+		return ret, 0
+	}
+
+	// Column j of the elevation matrix is the unit control point j of the
+	// reduced curve, raised to the original degree.
+	elevation := make([][]float64, degree+1)
+	for j := range elevation {
+		unit := make([]Pt, degree+1)
+		unit[j] = PtXy(1, 0)
+		raised := ElevatePts(unit, n)
+		elevation[j] = make([]float64, n+1)
+		for i, p := range raised {
+			elevation[j][i] = float64(p.X())
+		}
+	}
+
+	// Solve the normal equations for the inner points, with the end points
+	// fixed to the original end points.
+	inner := degree - 1
+	ata := make([][]float64, inner)
+	atb := make([][2]float64, inner)
+	for r := 0; r < inner; r++ {
+		ata[r] = make([]float64, inner)
+		for c := 0; c < inner; c++ {
+			for i := 0; i <= n; i++ {
+				ata[r][c] += elevation[r+1][i] * elevation[c+1][i]
+			}
+		}
+		for i := 0; i <= n; i++ {
+			x := float64(pts[i].X()) - elevation[0][i]*float64(pts[0].X()) - elevation[degree][i]*float64(pts[n].X())
+			y := float64(pts[i].Y()) - elevation[0][i]*float64(pts[0].Y()) - elevation[degree][i]*float64(pts[n].Y())
+			atb[r][0] += elevation[r+1][i] * x
+			atb[r][1] += elevation[r+1][i] * y
+		}
+	}
+	solution := solveLinearSystem(ata, atb)
+
+	ret := make([]Pt, degree+1)
+	ret[0], ret[degree] = pts[0], pts[n]
+	for h := 0; h < inner; h++ {
+		ret[h+1] = PtXy(Length(solution[h][0]), Length(solution[h][1]))
+	}
+
+	// The difference of the two curves is a curve whose control points are
+	// the differences of the control points.
+	diff := ElevatePts(ret, n)
+	for i := range diff {
+		diff[i] = PtOrig.Add(pts[i].VectorTo(diff[i]))
+	}
+	return ret, bezierDistanceBound(diff)
+}
+
+// bezierDistanceBound returns an upper bound on the distance from the origin
+// to the curve of \c pts. A curve stays inside the hull of its points, so the
+// furthest point is a bound. Splitting the curve tightens the bound, until it
+// is within 0.1% of the furthest point found on the curve.
+func bezierDistanceBound(pts []Pt) Length {
+	furthest := func(pts []Pt) Length {
+		var d Length
+		for _, p := range pts {
+			d = Maximum(d, PtOrig.VectorTo(p).Magnitude())
+		}
+		return d
+	}
+	// The ends are on the curve, so they are a lower bound.
+	lower := Maximum(PtOrig.VectorTo(pts[0]).Magnitude(), PtOrig.VectorTo(pts[len(pts)-1]).Magnitude())
+	upper := lower
+	type piece struct {
+		pts   []Pt
+		depth int
+	}
+	stack := []piece{{pts, 0}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		hull := furthest(p.pts)
+		if hull <= lower*(1+1e-3) || p.depth >= bezierReduceMaxDepth {
+			upper = Maximum(upper, hull)
+			continue
+		}
+		left, right := DeCasteljauSplit(p.pts, 0.5)
+		lower = Maximum(lower, PtOrig.VectorTo(right[0]).Magnitude())
+		stack = append(stack, piece{left, p.depth + 1}, piece{right, p.depth + 1})
+	}
+	return upper
+}
+
+// solveLinearSystem solves \c a x = \c b for x using Gaussian elimination
+// with partial pivoting. Each row of \c b holds an x and a y value.
+func solveLinearSystem(a [][]float64, b [][2]float64) [][2]float64 {
+	n := len(a)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(a[r][col]) > math.Abs(a[pivot][col]) {
+				pivot = r
+			}
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+		for r := col + 1; r < n; r++ {
+			f := a[r][col] / a[col][col]
+			for c := col; c < n; c++ {
+				a[r][c] -= f * a[col][c]
+			}
+			b[r][0] -= f * b[col][0]
+			b[r][1] -= f * b[col][1]
+		}
+	}
+	x := make([][2]float64, n)
+	for r := n - 1; r >= 0; r-- {
+		sx, sy := b[r][0], b[r][1]
+		for c := r + 1; c < n; c++ {
+			sx -= a[r][c] * x[c][0]
+			sy -= a[r][c] * x[c][1]
+		}
+		x[r] = [2]float64{sx / a[r][r], sy / a[r][r]}
+	}
+	return x
+}
+
+// BeziersFromPts approximates the curve of \c pts with cubic Bezier curves
+// that stray no more than \c tolerance from the curve. Curves of degree 3 or
+// less are elevated exactly into a single Bezier. Higher degree curves are
+// reduced with \c ReducePts, and split in half until each piece is within
+// tolerance. Returns the curves and the largest error bound of the pieces.
+//
+// Returns nil and a NaN bound if there are fewer than 2 points, or the
+// tolerance is not greater than zero.
+func BeziersFromPts(pts []Pt, tolerance Length) ([]Bezier, Length) {
+	if len(pts) < 2 || !(tolerance > 0) {
+		return nil, Length(math.NaN())
+	}
+	var split func([]Pt, int) ([]Bezier, Length)
+	split = func(pts []Pt, depth int) ([]Bezier, Length) {
+		reduced, bound := ReducePts(ElevatePts(pts, 3), 3)
+		if bound <= tolerance || depth >= bezierReduceMaxDepth {
+			return []Bezier{BezierPt(reduced[0], reduced[1], reduced[2], reduced[3])}, bound
+		}
+		left, right := DeCasteljauSplit(pts, 0.5)
+		a, abound := split(left, depth+1)
+		b, bbound := split(right, depth+1)
+		return append(a, b...), Maximum(abound, bbound)
+	}
+	return split(pts, 0)
+}
+
 // ParamCurve is a curve defined by a pair of parametric functions. It doesn't
 // provide a lot of functionality, but does provide an easy way to recreate
 // curves based on polynomial equations.
@@ -151,6 +344,20 @@ func ParamQuartic(p1, p2, p3, p4, p5 Pt) ParamCurve {
 	}
 }
 
+// AppendFlatten appends the points of \c Flatten to \c dst, and returns the
+// extended slice. Reading the coefficients of the curve may allocate, but the
+// points don't when \c dst has enough capacity.
+func (pc ParamCurve) AppendFlatten(dst []Pt, tolerance Length) []Pt {
+	if !(tolerance > 0) {
+		return dst
+	}
+	if _, err := pc.Begin().OrErr(); err != nil {
+		return dst
+	}
+	dst = append(dst, pc.PtAtT(pc.Min))
+	return appendFlatParamCurve(dst, pc, tolerance)
+}
+
 // ApproxLength treats the curve as \c steps number of line segments and returns
 // the sum of the length of all the line segments. It isn't as accurate as the
 // \c Length() function, but can be much faster for smaller values of \c steps.
@@ -167,6 +374,13 @@ func (pc ParamCurve) ApproxLength(steps int) Length {
 	return sum
 }
 
+// ArcLengthTable returns a table that maps between \c t and the distance
+// along the curve, divided into \c entries equal steps of \c t. Use a table
+// when looking up many lengths on the same curve.
+func (pc ParamCurve) ArcLengthTable(entries int) ArcLengthTable {
+	return arcLengthTablePolynomial(pc.X, pc.Y, pc.Min, pc.Max, entries, pc.PtAtT)
+}
+
 // Begin returns the first point of the param curve. The point at the \c Min
 // value of the curve.
 func (pc ParamCurve) Begin() Pt { return pc.PtAtT(pc.Min) }
@@ -188,10 +402,34 @@ func (pc ParamCurve) BoundingBox() Rectangle {
 	return RectanglePt(PtXy(lx, ly), PtXy(mx, my))
 }
 
+// ClosestPt returns the value of \c t, the point, and the distance of the
+// point of the curve closest to \c p.
+func (pc ParamCurve) ClosestPt(p Pt) (float64, Pt, Length) {
+	return closestPtPolynomial(p, pc.X, pc.Y, pc.Min, pc.Max, pc.PtAtT)
+}
+
+// CurvatureAtT returns the signed curvature of the curve at \c t. Positive
+// curvature turns counter-clockwise (left), and negative curvature turns
+// clockwise (right). Returns NaN at a cusp.
+func (pc ParamCurve) CurvatureAtT(t float64) float64 { return curvatureAtT(pc.X, pc.Y, t) }
+
 // End returns the last point of the param curve. The point at the \c Max
 // value of the curve.
 func (pc ParamCurve) End() Pt { return pc.PtAtT(pc.Max) }
 
+// EquidistantPts returns \c n points along the curve, with the same distance
+// along the curve between each point and the next. See
+// \c ArcLengthTable.EquidistantPts.
+func (pc ParamCurve) EquidistantPts(n int) []Pt {
+	return pc.ArcLengthTable(arcLengthTableEntries).EquidistantPts(n)
+}
+
+// Flatten returns the points of a polyline that is no more than \c tolerance
+// from any point of the curve. The curve is halved until each piece is
+// straight within tolerance, so straight parts use fewer points than tight
+// bends. See \c SegmentsFromPts. The tolerance must be greater than zero.
+func (pc ParamCurve) Flatten(tolerance Length) []Pt { return pc.AppendFlatten(nil, tolerance) }
+
 // Length returns a more accurate approximation of length than ApproxLength.
 func (pc ParamCurve) Length() Length {
 	// see https://pomax.github.io/bezierinfo/legendre-gauss.html
@@ -213,6 +451,28 @@ func (pc ParamCurve) Length() Length {
 	return Length(sum * halfz)
 }
 
+// LengthAtT returns the distance along the curve from its beginning to \c t.
+func (pc ParamCurve) LengthAtT(t float64) Length {
+	return lengthAtTPolynomial(pc.X, pc.Y, pc.Min, pc.Max, t)
+}
+
+// MaxCurvatureTs returns the values of \c t where the magnitude of the
+// curvature has a local maximum, including cusps. Straight curves have no
+// maximum.
+func (pc ParamCurve) MaxCurvatureTs() []float64 { return maxCurvatureTs(pc.X, pc.Y, pc.Min, pc.Max) }
+
+// OsculatingCircleAtT returns the circle that best matches the curve at
+// \c t. The circle is in error where the curve is straight, or at a cusp.
+func (pc ParamCurve) OsculatingCircleAtT(t float64) Circle {
+	tangent, _ := pc.TangentAtT(t)
+	return osculatingCircle(pc.PtAtT(t), tangent, pc.CurvatureAtT(t))
+}
+
+// PtAtLength returns the point \c length along the curve from its beginning.
+func (pc ParamCurve) PtAtLength(length Length) Pt {
+	return pc.PtAtT(pc.TAtLength(length))
+}
+
 // PtAtT returns the point for the provided value of \c t.
 func (pc ParamCurve) PtAtT(t float64) Pt {
 	t = Clamp(pc.Min, t, pc.Max)
@@ -220,6 +480,12 @@ func (pc ParamCurve) PtAtT(t float64) Pt {
 	return PtXy(Length(x), Length(y))
 }
 
+// RadiusOfCurvatureAtT returns the radius of the osculating circle at \c t.
+// The radius is infinite where the curve is straight, and NaN at a cusp.
+func (pc ParamCurve) RadiusOfCurvatureAtT(t float64) Length {
+	return radiusOfCurvature(pc.CurvatureAtT(t))
+}
+
 // Roots returns the roots for the current curve. This is a helper function
 // that filters the root values between the \c Min and \c Max values before
 // returning them.
@@ -264,48 +530,10 @@ func (pc ParamCurve) SplitAtT(t float64) (ParamCurve, ParamCurve) {
 }
 
 // SplitAtLength splits a param curve into two different param curves, with the
-// first curve \c length long, and the second curve as the remained. The
-// returned curves are the approximate length, as the function performs a
-// constrainted binary search to find the splitting point.
+// first curve \c length long, and the second curve as the remainder. See
+// \c TAtLength.
 func (pc ParamCurve) SplitAtLength(length Length) (ParamCurve, ParamCurve) {
-	const (
-		windowTarget = 0.01
-		windowShrink = 0.75
-	)
-
-	if IsZero(length) || length < 0 {
-		a := pc
-		a.Max = a.Min
-		return a, pc
-	}
-
-	curveLength := pc.Length()
-	if IsEqual(curveLength, length) || curveLength < length {
-		a := pc
-		a.Min = a.Max
-		return pc, a
-	}
-
-	guess := float64(length/curveLength) * (pc.Max - pc.Min)
-	window := 1.0
-
-	var a, b ParamCurve
-	for window > windowTarget {
-		t := pc.Min + guess
-		a, b = pc.SplitAtT(t)
-		alength := a.Length()
-		if IsEqual(alength, length) {
-			return a, b
-		} else if alength > length {
-			delta := float64((alength-length)/curveLength) * window
-			guess -= delta
-		} else {
-			delta := float64((length-alength)/curveLength) * window
-			guess += delta
-		}
-		window = window * windowShrink
-	}
-	return a, b
+	return pc.SplitAtT(pc.TAtLength(length))
 }
 
 // String returns a string representation of the ParamCurve. Format allows the
@@ -321,6 +549,13 @@ func (pc ParamCurve) String() string {
 	)
 }
 
+// TAtLength returns the value of \c t that is \c length along the curve from
+// its beginning. Lengths outside the curve are clamped to the curve. Use an
+// \c ArcLengthTable when looking up many lengths on the same curve.
+func (pc ParamCurve) TAtLength(length Length) float64 {
+	return tAtLengthPolynomial(pc.X, pc.Y, pc.Min, pc.Max, length)
+}
+
 // TangentAtT returns the tangent and the normal of the curve for the given
 // value of \c t.
 func (pc ParamCurve) TangentAtT(t float64) (Vector, Vector) {
@@ -384,6 +619,21 @@ func (curve Bezier) AlignOnX() (Vector, Radians, Length, Bezier) {
 	return translate, theta, scale, BezierPt(pts[0], pts[1], pts[2], pts[3])
 }
 
+// AppendFlatten appends the points of \c Flatten to \c dst, and returns the
+// extended slice. It doesn't allocate when \c dst has enough capacity.
+func (curve Bezier) AppendFlatten(dst []Pt, tolerance Length) []Pt {
+	if !(tolerance > 0) {
+		return dst
+	}
+	for _, p := range curve.pts {
+		if _, err := p.OrErr(); err != nil {
+			return dst
+		}
+	}
+	dst = append(dst, curve.pts[0])
+	return appendFlatPts(dst, curve.pts[:], tolerance)
+}
+
 // ApproxLength treats the curve \c steps number of line segments and returns
 // the sum of the length of all the line segments. It isn't as accurate as the
 // \c Length() function, but can be much faster for smaller values of \c steps.
@@ -399,6 +649,13 @@ func (curve Bezier) ApproxLength(steps int) Length {
 	return sum
 }
 
+// ArcLengthTable returns a table that maps between \c t and the distance
+// along the curve, divided into \c entries equal steps of \c t. Use a table
+// when looking up many lengths on the same curve.
+func (curve Bezier) ArcLengthTable(entries int) ArcLengthTable {
+	return arcLengthTablePolynomial(curve.x, curve.y, 0, 1, entries, curve.PtAtT)
+}
+
 func (curve Bezier) Begin() Pt { return curve.pts[0] }
 
 // BoundingBox returns an axis-aligned rectangle that encompasses all the
@@ -483,8 +740,36 @@ func (curve Bezier) CurveType() BezierCurveType {
 	return BEZIER_CURVE_TYPE_PLAIN
 }
 
+// ClosestPt returns the value of \c t, the point, and the distance of the
+// point of the curve closest to \c p.
+func (curve Bezier) ClosestPt(p Pt) (float64, Pt, Length) {
+	return closestPtPolynomial(p, curve.x, curve.y, 0, 1, curve.PtAtT)
+}
+
+// CurvatureAtT returns the signed curvature of the curve at \c t. Positive
+// curvature turns counter-clockwise (left), and negative curvature turns
+// clockwise (right). Returns NaN at a cusp.
+func (curve Bezier) CurvatureAtT(t float64) float64 { return curvatureAtT(curve.x, curve.y, t) }
+
 func (curve Bezier) End() Pt { return curve.pts[3] }
 
+// EquidistantPts returns \c n points along the curve, with the same distance
+// along the curve between each point and the next. See
+// \c ArcLengthTable.EquidistantPts.
+func (curve Bezier) EquidistantPts(n int) []Pt {
+	return curve.ArcLengthTable(arcLengthTableEntries).EquidistantPts(n)
+}
+
+// Flatten returns the points of a polyline that is no more than \c tolerance
+// from any point of the curve. The curve is halved until each piece is
+// straight within tolerance, so straight parts use fewer points than tight
+// bends. See \c SegmentsFromPts. The tolerance must be greater than zero.
+func (curve Bezier) Flatten(tolerance Length) []Pt { return curve.AppendFlatten(nil, tolerance) }
+
+// HullPts implements the HullPtser interface. The hull of the control points
+// contains the curve.
+func (curve Bezier) HullPts(tolerance Length) []Pt { return curve.Points() }
+
 // InflectionPts returns the points where the curvature of the curve switches
 // directions.
 func (curve Bezier) InflectionPts() []float64 {
@@ -531,16 +816,44 @@ func (curve Bezier) Length() Length {
 	return Length(sum * (z / 2))
 }
 
+// LengthAtT returns the distance along the curve from its beginning to \c t.
+func (curve Bezier) LengthAtT(t float64) Length {
+	return lengthAtTPolynomial(curve.x, curve.y, 0, 1, t)
+}
+
+// MaxCurvatureTs returns the values of \c t where the magnitude of the
+// curvature has a local maximum, including cusps. Straight curves have no
+// maximum.
+func (curve Bezier) MaxCurvatureTs() []float64 { return maxCurvatureTs(curve.x, curve.y, 0, 1) }
+
+// OsculatingCircleAtT returns the circle that best matches the curve at
+// \c t. The circle is in error where the curve is straight, or at a cusp.
+func (curve Bezier) OsculatingCircleAtT(t float64) Circle {
+	tangent, _ := curve.TangentAtT(t)
+	return osculatingCircle(curve.PtAtT(t), tangent, curve.CurvatureAtT(t))
+}
+
 // Points provides access to the individual points of this curve. Consider the
 // points readonly.
 func (curve Bezier) Points() []Pt { return curve.pts[:] }
 
+// PtAtLength returns the point \c length along the curve from its beginning.
+func (curve Bezier) PtAtLength(length Length) Pt {
+	return curve.PtAtT(curve.TAtLength(length))
+}
+
 // PtAtT returns the point for the provided value of \c t.
 func (curve Bezier) PtAtT(t float64) Pt {
 	x, y := curve.x.AtT(t), curve.y.AtT(t)
 	return PtXy(Length(x), Length(y))
 }
 
+// RadiusOfCurvatureAtT returns the radius of the osculating circle at \c t.
+// The radius is infinite where the curve is straight, and NaN at a cusp.
+func (curve Bezier) RadiusOfCurvatureAtT(t float64) Length {
+	return radiusOfCurvature(curve.CurvatureAtT(t))
+}
+
 // Roots returns the roots for the current curve. See Also Bezier.AlignOnX()
 // and RotateOrTranslateToXAxis()
 func (curve Bezier) Roots() ([]float64, []float64) {
@@ -573,6 +886,12 @@ func (curve Bezier) Roots() ([]float64, []float64) {
 	return xroots, yroots
 }
 
+// SplitAtLength splits the curve into two curves, with the first curve
+// \c length long, and the second curve as the remainder. See \c TAtLength.
+func (curve Bezier) SplitAtLength(length Length) (Bezier, Bezier) {
+	return curve.SplitAtT(curve.TAtLength(length))
+}
+
 // SplitAtT splits the current Bezier into 2 distinct bezier that have the are
 // the same curvature.
 func (curve Bezier) SplitAtT(t float64) (Bezier, Bezier) {
@@ -643,6 +962,13 @@ func (curve Bezier) String() string {
 	)
 }
 
+// TAtLength returns the value of \c t that is \c length along the curve from
+// its beginning. Lengths outside the curve are clamped to the curve. Use an
+// \c ArcLengthTable when looking up many lengths on the same curve.
+func (curve Bezier) TAtLength(length Length) float64 {
+	return tAtLengthPolynomial(curve.x, curve.y, 0, 1, length)
+}
+
 // TangentAtT returns the tangent and the normal of the curve for the given
 // value of \c t.
 func (curve Bezier) TangentAtT(t float64) (Vector, Vector) {
@@ -652,3 +978,374 @@ func (curve Bezier) TangentAtT(t float64) (Vector, Vector) {
 	normal := VectorIj(-Length(j), Length(i))
 	return tangent, normal
 }
+
+// Represents a Quadratic Bezier Curve.
+//
+// Quadratic curves are common in font outlines. The QuadraticBezier type
+// provides the same functions as the Bezier type, and \c Elevate converts it
+// into an identical cubic Bezier when a cubic is required.
+type QuadraticBezier struct {
+	pts  [3]Pt
+	x, y Quadratic
+}
+
+// QuadraticBezierPt creates a new quadratic Bezier curve based on the
+// provided points.
+func QuadraticBezierPt(p1, p2, p3 Pt) QuadraticBezier {
+	px := mgl64.Vec3{float64(p3.X()), float64(p2.X()), float64(p1.X())}
+	py := mgl64.Vec3{float64(p3.Y()), float64(p2.Y()), float64(p1.Y())}
+	xs, ys := MatrixBezierQuadratic.Mul3x1(px), MatrixBezierQuadratic.Mul3x1(py)
+	return QuadraticBezier{
+		pts: [3]Pt{p1, p2, p3},
+		x:   QuadraticFromVec3(xs),
+		y:   QuadraticFromVec3(ys),
+	}
+}
+
+// AlignOnX rotates, translates, and scales the curve to the X-Axis, with the
+// first point on the origin and the last point (1,0). If the last point is at
+// zero on the x-axis, it skips the scale operation.
+func (curve QuadraticBezier) AlignOnX() (Vector, Radians, Length, QuadraticBezier) {
+	translate := curve.pts[0].VectorTo(PtOrig)
+	pts := TranslatePts(translate, curve.Points())
+	theta := -PtOrig.VectorTo(pts[2]).Angle()
+	pts = RotatePts(theta, PtOrig, pts)
+	scale := pts[2].X()
+	if !IsZero(scale) {
+		pts = ScalePts(VectorIj(1/scale, 1/scale), pts)
+	}
+
+	return translate, theta, scale, QuadraticBezierPt(pts[0], pts[1], pts[2])
+}
+
+// AppendFlatten appends the points of \c Flatten to \c dst, and returns the
+// extended slice. It doesn't allocate when \c dst has enough capacity.
+func (curve QuadraticBezier) AppendFlatten(dst []Pt, tolerance Length) []Pt {
+	if !(tolerance > 0) {
+		return dst
+	}
+	for _, p := range curve.pts {
+		if _, err := p.OrErr(); err != nil {
+			return dst
+		}
+	}
+	dst = append(dst, curve.pts[0])
+	return appendFlatPts(dst, curve.pts[:], tolerance)
+}
+
+// ApproxLength treats the curve \c steps number of line segments and returns
+// the sum of the length of all the line segments.
+func (curve QuadraticBezier) ApproxLength(steps int) Length {
+	prev := curve.PtAtT(0)
+	var sum Length
+	for h := 1; h <= steps; h++ {
+		t := 1.0 / float64(steps) * float64(h)
+		curr := curve.PtAtT(t)
+		sum += prev.VectorTo(curr).Magnitude()
+		prev = curr
+	}
+	return sum
+}
+
+// ArcLengthTable returns a table that maps between \c t and the distance
+// along the curve, divided into \c entries equal steps of \c t. Use a table
+// when looking up many lengths on the same curve.
+func (curve QuadraticBezier) ArcLengthTable(entries int) ArcLengthTable {
+	return arcLengthTablePolynomial(curve.x, curve.y, 0, 1, entries, curve.PtAtT)
+}
+
+func (curve QuadraticBezier) Begin() Pt { return curve.pts[0] }
+
+// BoundingBox returns an axis-aligned rectangle that encompasses all the
+// points of the curve.
+func (curve QuadraticBezier) BoundingBox() Rectangle {
+	ieq, jeq := curve.x.FirstDerivative(), curve.y.FirstDerivative()
+	roots := []float64{0.0, 1.0}
+	roots = append(roots, ieq.Roots()...)
+	roots = append(roots, jeq.Roots()...)
+	pts := make([]Pt, 0, len(roots))
+	for h := 0; h < len(roots); h++ {
+		if 0 <= roots[h] && roots[h] <= 1.0 {
+			pts = append(pts, curve.PtAtT(roots[h]))
+		}
+	}
+	lx, mx, ly, my := LimitsPts(pts)
+	return RectanglePt(PtXy(lx, ly), PtXy(mx, my))
+}
+
+// FastBox returns an axis aligned rectangle that would include the convex
+// hull of the curve. This is faster than BoundingBox, but not as tightly
+// packed.
+func (curve QuadraticBezier) FastBox() Rectangle {
+	lx, mx, ly, my := LimitsPts(curve.pts[:])
+	return Rectangle{
+		pts: [2]Pt{PtXy(lx, ly), PtXy(mx, my)},
+	}
+}
+
+// CurveType returns the type of curve this is. A quadratic curve can not
+// loop or inflect, so it is either plain, or a cusp when all the points are
+// on a line and the curve doubles back on itself.
+func (curve QuadraticBezier) CurveType() BezierCurveType {
+	v1 := curve.pts[0].VectorTo(curve.pts[1])
+	v2 := curve.pts[1].VectorTo(curve.pts[2])
+	v1i, v1j := v1.Units()
+	v2i, v2j := v2.Units()
+	if IsZero(v1i*v2j-v1j*v2i) && v1.Dot(v2) < 0 {
+		return BEZIER_CURVE_TYPE_CUSP
+	}
+	return BEZIER_CURVE_TYPE_PLAIN
+}
+
+// CurvatureAtT returns the signed curvature of the curve at \c t. Positive
+// curvature turns counter-clockwise (left), and negative curvature turns
+// clockwise (right). Returns NaN at a cusp.
+func (curve QuadraticBezier) CurvatureAtT(t float64) float64 {
+	return curvatureAtT(curve.x, curve.y, t)
+}
+
+// Elevate returns the cubic Bezier that draws exactly the same curve.
+func (curve QuadraticBezier) Elevate() Bezier {
+	// see https://pomax.github.io/bezierinfo/#reordering
+	p1, p2, p3 := curve.pts[0], curve.pts[1], curve.pts[2]
+	return BezierPt(
+		p1,
+		p1.Add(p1.VectorTo(p2).Scale(2.0/3.0)),
+		p3.Add(p3.VectorTo(p2).Scale(2.0/3.0)),
+		p3,
+	)
+}
+
+// ClosestPt returns the value of \c t, the point, and the distance of the
+// point of the curve closest to \c p.
+func (curve QuadraticBezier) ClosestPt(p Pt) (float64, Pt, Length) {
+	return closestPtPolynomial(p, curve.x, curve.y, 0, 1, curve.PtAtT)
+}
+
+func (curve QuadraticBezier) End() Pt { return curve.pts[2] }
+
+// EquidistantPts returns \c n points along the curve, with the same distance
+// along the curve between each point and the next. See
+// \c ArcLengthTable.EquidistantPts.
+func (curve QuadraticBezier) EquidistantPts(n int) []Pt {
+	return curve.ArcLengthTable(arcLengthTableEntries).EquidistantPts(n)
+}
+
+// Flatten returns the points of a polyline that is no more than \c tolerance
+// from any point of the curve. The curve is halved until each piece is
+// straight within tolerance, so straight parts use fewer points than tight
+// bends. See \c SegmentsFromPts. The tolerance must be greater than zero.
+func (curve QuadraticBezier) Flatten(tolerance Length) []Pt {
+	return curve.AppendFlatten(nil, tolerance)
+}
+
+// HullPts implements the HullPtser interface. The hull of the control points
+// contains the curve.
+func (curve QuadraticBezier) HullPts(tolerance Length) []Pt { return curve.Points() }
+
+// InflectionPts returns the points where the curvature of the curve switches
+// directions. Quadratic curves never switch directions, so this is always
+// empty.
+func (curve QuadraticBezier) InflectionPts() []float64 { return nil }
+
+// Length returns a more accurate approximation than ApproxLength.
+func (curve QuadraticBezier) Length() Length {
+	ieq, jeq := curve.x.FirstDerivative(), curve.y.FirstDerivative()
+	speed := func(t float64) float64 {
+		x, y := ieq.AtT(t), jeq.AtT(t)
+		return math.Sqrt(x*x + y*y)
+	}
+	// The speed is slowest where the derivative is closest to zero, which is
+	// a cusp when the points are on a line. Integrate either side of it.
+	ia, ib := ieq.Ab()
+	ja, jb := jeq.Ab()
+	if a := ia*ia + ja*ja; !IsZero(a) {
+		if slowest := -(ia*ib + ja*jb) / a; 0 < slowest && slowest < 1 {
+			return Length(legendreGaussIntegrate(speed, 0, slowest) +
+				legendreGaussIntegrate(speed, slowest, 1))
+		}
+	}
+	return Length(legendreGaussIntegrate(speed, 0, 1))
+}
+
+// LengthAtT returns the distance along the curve from its beginning to \c t.
+func (curve QuadraticBezier) LengthAtT(t float64) Length {
+	return lengthAtTPolynomial(curve.x, curve.y, 0, 1, t)
+}
+
+// MaxCurvatureTs returns the values of \c t where the magnitude of the
+// curvature has a local maximum, including cusps. Straight curves have no
+// maximum.
+func (curve QuadraticBezier) MaxCurvatureTs() []float64 {
+	return maxCurvatureTs(curve.x, curve.y, 0, 1)
+}
+
+// OsculatingCircleAtT returns the circle that best matches the curve at
+// \c t. The circle is in error where the curve is straight, or at a cusp.
+func (curve QuadraticBezier) OsculatingCircleAtT(t float64) Circle {
+	tangent, _ := curve.TangentAtT(t)
+	return osculatingCircle(curve.PtAtT(t), tangent, curve.CurvatureAtT(t))
+}
+
+// Points provides access to the individual points of this curve. Consider the
+// points readonly.
+func (curve QuadraticBezier) Points() []Pt { return curve.pts[:] }
+
+// PtAtLength returns the point \c length along the curve from its beginning.
+func (curve QuadraticBezier) PtAtLength(length Length) Pt {
+	return curve.PtAtT(curve.TAtLength(length))
+}
+
+// PtAtT returns the point for the provided value of \c t.
+func (curve QuadraticBezier) PtAtT(t float64) Pt {
+	x, y := curve.x.AtT(t), curve.y.AtT(t)
+	return PtXy(Length(x), Length(y))
+}
+
+// RadiusOfCurvatureAtT returns the radius of the osculating circle at \c t.
+// The radius is infinite where the curve is straight, and NaN at a cusp.
+func (curve QuadraticBezier) RadiusOfCurvatureAtT(t float64) Length {
+	return radiusOfCurvature(curve.CurvatureAtT(t))
+}
+
+// Roots returns the roots for the current curve. See Also
+// QuadraticBezier.AlignOnX() and RotateOrTranslateToXAxis()
+func (curve QuadraticBezier) Roots() ([]float64, []float64) {
+	filter := func(rs []float64) []float64 {
+		roots := make([]float64, 0, len(rs))
+		for _, r := range rs {
+			if IsZero(r) {
+				r = 0
+			} else if IsZero(1.0 - r) {
+				r = 1
+			}
+			if 0 <= r && r <= 1.0 {
+				roots = append(roots, r)
+			}
+		}
+		return roots
+	}
+	return filter(curve.x.Roots()), filter(curve.y.Roots())
+}
+
+// SplitAtLength splits the curve into two curves, with the first curve
+// \c length long, and the second curve as the remainder. See \c TAtLength.
+func (curve QuadraticBezier) SplitAtLength(length Length) (QuadraticBezier, QuadraticBezier) {
+	return curve.SplitAtT(curve.TAtLength(length))
+}
+
+// SplitAtT splits the current curve into 2 distinct curves that have the
+// same curvature.
+func (curve QuadraticBezier) SplitAtT(t float64) (QuadraticBezier, QuadraticBezier) {
+	left, right := DeCasteljauSplit(curve.pts[:], t)
+	return QuadraticBezierPt(left[0], left[1], left[2]),
+		QuadraticBezierPt(right[0], right[1], right[2])
+}
+
+// TightBox returns a rectangle aligned with the line from the first point to
+// the last point that encompasses all the points of the curve.
+func (curve QuadraticBezier) TightBox() Polygon {
+	translate, rotate, scale, aligned := curve.AlignOnX()
+	boundingBox := aligned.BoundingBox()
+	box := PolygonFromRectangle(boundingBox)
+	if !IsZero(scale) {
+		box = box.Scale(VectorIj(scale, scale))
+	}
+	box = box.Rotate(-rotate, PtOrig).Translate(translate.Invert())
+	return box
+}
+
+// String returns a string representation of the curve. Format allows the
+// curve to be pasted into Geogebra.
+func (curve QuadraticBezier) String() string {
+	unknown := 't'
+	return fmt.Sprintf("QuadraticBezier[ Curve(%s, %s, %c, 0, 1) ]",
+		curve.x.Text(unknown, false),
+		curve.y.Text(unknown, false),
+		unknown,
+	)
+}
+
+// TAtLength returns the value of \c t that is \c length along the curve from
+// its beginning. Lengths outside the curve are clamped to the curve. Use an
+// \c ArcLengthTable when looking up many lengths on the same curve.
+func (curve QuadraticBezier) TAtLength(length Length) float64 {
+	return tAtLengthPolynomial(curve.x, curve.y, 0, 1, length)
+}
+
+// TangentAtT returns the tangent and the normal of the curve for the given
+// value of \c t.
+func (curve QuadraticBezier) TangentAtT(t float64) (Vector, Vector) {
+	ieq, jeq := curve.x.FirstDerivative(), curve.y.FirstDerivative()
+	i, j := ieq.AtT(t), jeq.AtT(t)
+	tangent := VectorIj(Length(i), Length(j))
+	normal := VectorIj(-Length(j), Length(i))
+	return tangent, normal
+}
+
+// closestPtPolynomial returns the value of \c t between \c lo and \c hi, the
+// point, and the distance of the point of the curve closest to \c p. The
+// curve is found from the coefficients of its functions when they provide
+// them, and by searching along the curve otherwise.
+func closestPtPolynomial(p Pt, x, y Derivable, lo, hi float64, ptAtT func(float64) Pt) (float64, Pt, Length) {
+	xc, xok := x.(Coefficienter)
+	yc, yok := y.(Coefficienter)
+	xd, xdok := x.Derivative().(Coefficienter)
+	yd, ydok := y.Derivative().(Coefficienter)
+
+	var candidates []float64
+	if xok && yok && xdok && ydok {
+		// The distance is smallest at the ends, or where the curve is
+		// perpendicular to the direction to p: (c(t) - p) . c'(t) = 0.
+		// For a cubic this is a quintic. Cusps have c'(t) = 0, so they are
+		// roots too.
+		px, py := p.XY()
+		dx := polynomialAdd(xc.Coefficients(), []float64{-float64(px)})
+		dy := polynomialAdd(yc.Coefficients(), []float64{-float64(py)})
+		coeffs := polynomialAdd(
+			polynomialMul(dx, xd.Coefficients()),
+			polynomialMul(dy, yd.Coefficients()),
+		)
+		candidates = polynomialRoots(coeffs, lo, hi)
+	} else {
+		candidates = []float64{closestTSampled(p, lo, hi, ptAtT)}
+	}
+
+	bestT, bestPt := lo, ptAtT(lo)
+	bestD := p.VectorTo(bestPt).Magnitude()
+	for _, t := range append(candidates, hi) {
+		if c := ptAtT(t); p.VectorTo(c).Magnitude() < bestD {
+			bestT, bestPt, bestD = t, c, p.VectorTo(c).Magnitude()
+		}
+	}
+	return bestT, bestPt, bestD
+}
+
+// closestTSampled returns the value of \c t between \c lo and \c hi of the
+// sample closest to \c p, refined with a golden section search between its
+// neighbors.
+func closestTSampled(p Pt, lo, hi float64, ptAtT func(float64) Pt) float64 {
+	const samples = 64
+	dist := func(t float64) Length { return p.VectorTo(ptAtT(t)).Magnitude() }
+	step := (hi - lo) / samples
+	best, bestD := lo, dist(lo)
+	for h := 1; h <= samples; h++ {
+		if d := dist(lo + step*float64(h)); d < bestD {
+			best, bestD = lo+step*float64(h), d
+		}
+	}
+	a, b := math.Max(lo, best-step), math.Min(hi, best+step)
+	// see https://en.wikipedia.org/wiki/Golden-section_search
+	invphi := (math.Sqrt(5) - 1) / 2
+	c, d := b-invphi*(b-a), a+invphi*(b-a)
+	for h := 0; h < 60; h++ {
+		if dist(c) < dist(d) {
+			b, d = d, c
+			c = b - invphi*(b-a)
+		} else {
+			a, c = c, d
+			d = a + invphi*(b-a)
+		}
+	}
+	return (a + b) / 2
+}
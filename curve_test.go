@@ -79,6 +79,155 @@ func TestDeCasteljauSplit(t *testing.T) {
 	}
 }
 
+func TestElevateReducePts(t *testing.T) {
+	quartic := []Pt{
+		PtXy(-2.42, -8.24), PtXy(-0.14, -2.94), PtXy(5.74, -8.84),
+		PtXy(9.96, 0.4), PtXy(13.78, -5.2),
+	}
+	cubic := []Pt{PtXy(396, 34), PtXy(89, 120), PtXy(199, 295), PtXy(260, 80)}
+
+	elevateTests := []struct {
+		pts    []Pt
+		degree int
+		out    []Pt
+	}{
+		{
+			//0
+			[]Pt{PtXy(0, 0), PtXy(1, 2), PtXy(2, 0)}, 3,
+			[]Pt{PtXy(0, 0), PtXy(0.666666667, 1.333333333), PtXy(1.333333333, 1.333333333), PtXy(2, 0)},
+		}, {
+			[]Pt{PtXy(0, 0), PtXy(3, 3)}, 3,
+			[]Pt{PtXy(0, 0), PtXy(1, 1), PtXy(2, 2), PtXy(3, 3)},
+		}, {
+			cubic, 2,
+			cubic,
+		}, {
+			nil, 3,
+			[]Pt{},
+		},
+	}
+	for h, test := range elevateTests {
+		out := ElevatePts(test.pts, test.degree)
+		if len(out) != len(test.out) {
+			t.Errorf("[%d]ElevatePts(%v, %d) (length) failed. %v != %v",
+				h, test.pts, test.degree, out, test.out)
+			continue
+		}
+		for i := range out {
+			if !IsEqualPair(out[i], test.out[i]) {
+				t.Errorf("[%d][%d]ElevatePts(%v, %d) failed. %v != %v",
+					h, i, test.pts, test.degree, out[i], test.out[i])
+			}
+		}
+		if len(test.pts) > 0 {
+			a, b := ParamPts(test.pts...), ParamPts(out...)
+			for _, tv := range []float64{0.25, 0.5, 0.75} {
+				if !IsEqualPair(a.PtAtT(tv), b.PtAtT(tv)) {
+					t.Errorf("[%d]ElevatePts(%v, %d) (curve) failed. %v != %v",
+						h, test.pts, test.degree, a.PtAtT(tv), b.PtAtT(tv))
+				}
+			}
+		}
+	}
+
+	reduceTests := []struct {
+		pts    []Pt
+		degree int
+		out    []Pt
+		bound  Length
+	}{
+		{
+			//0
+			ElevatePts(cubic, 5), 3,
+			cubic, 0,
+		}, {
+			quartic, 3,
+			[]Pt{PtXy(-2.42, -8.24), PtXy(1.131372549, -5.590588235), PtXy(9.198039216, -2.150588235), PtXy(13.78, -5.2)},
+			0.784865547276,
+		}, {
+			quartic, 2,
+			[]Pt{PtXy(-2.42, -8.24), PtXy(4.907058824, -2.445882353), PtXy(13.78, -5.2)},
+			1.132444205369,
+		}, {
+			quartic, 0,
+			[]Pt{PtXy(-2.42, -8.24), PtXy(13.78, -5.2)},
+			2.450865075733,
+		}, {
+			cubic, 3,
+			cubic, 0,
+		},
+	}
+	for h, test := range reduceTests {
+		out, bound := ReducePts(test.pts, test.degree)
+		if len(out) != len(test.out) {
+			t.Errorf("[%d]ReducePts(%v, %d) (length) failed. %v != %v",
+				h, test.pts, test.degree, out, test.out)
+			continue
+		}
+		for i := range out {
+			if !IsEqualPair(out[i], test.out[i]) {
+				t.Errorf("[%d][%d]ReducePts(%v, %d) failed. %v != %v",
+					h, i, test.pts, test.degree, out[i], test.out[i])
+			}
+		}
+		if !IsEqual(bound, test.bound) && !IsZero(bound-test.bound) {
+			t.Errorf("[%d]ReducePts(%v, %d) (bound) failed. %v != %v",
+				h, test.pts, test.degree, bound, test.bound)
+		}
+		// The bound holds at every t.
+		ptAtT := func(pts []Pt, tv float64) Pt {
+			left, _ := DeCasteljauSplit(pts, tv)
+			return left[len(left)-1]
+		}
+		for i := 0; i <= 100; i++ {
+			tv := float64(i) / 100
+			if d := ptAtT(test.pts, tv).VectorTo(ptAtT(out, tv)).Magnitude(); d > bound+1e-9 {
+				t.Errorf("[%d]ReducePts(%v, %d) (t=%f) failed. %v > %v",
+					h, test.pts, test.degree, tv, d, bound)
+			}
+		}
+	}
+
+	beziersTests := []struct {
+		pts       []Pt
+		tolerance Length
+		count     int
+	}{
+		{
+			//0
+			quartic, 1, 1,
+		}, {
+			quartic, 0.1, 2,
+		}, {
+			quartic, 0.01, 4,
+		}, {
+			[]Pt{PtXy(0, 0), PtXy(1, 2), PtXy(2, 0)}, 0.01, 1,
+		},
+	}
+	for h, test := range beziersTests {
+		curves, bound := BeziersFromPts(test.pts, test.tolerance)
+		if len(curves) != test.count || bound > test.tolerance {
+			t.Errorf("[%d]BeziersFromPts(%v, %f) failed. %d != %d, %v",
+				h, test.pts, test.tolerance, len(curves), test.count, bound)
+			continue
+		}
+		if !IsEqualPair(curves[0].Begin(), test.pts[0]) || !IsEqualPair(curves[len(curves)-1].End(), test.pts[len(test.pts)-1]) {
+			t.Errorf("[%d]BeziersFromPts(%v, %f) (ends) failed. %v",
+				h, test.pts, test.tolerance, curves)
+		}
+		for i := 1; i < len(curves); i++ {
+			if !IsEqualPair(curves[i-1].End(), curves[i].Begin()) {
+				t.Errorf("[%d][%d]BeziersFromPts(%v, %f) (continuity) failed. %v != %v",
+					h, i, test.pts, test.tolerance, curves[i-1].End(), curves[i].Begin())
+			}
+		}
+	}
+
+	if curves, bound := BeziersFromPts(quartic[:1], 1); curves != nil || !math.IsNaN(float64(bound)) {
+		t.Errorf("BeziersFromPts() with one point failed. %v, %v", curves, bound)
+	}
+}
+
 func TestParamCurve(t *testing.T) {
 	linearTests := []struct {
 		p1, p2   Pt
@@ -1105,6 +1254,128 @@ func TestBezier(t *testing.T) {
 	// TODO Not sure how I want this to work.
 }
 
+func TestQuadraticBezier(t *testing.T) {
+	identityTests := []struct {
+		p1, p2, p3 Pt
+		s          string
+		p33, p50   Pt
+		box        Rectangle
+		length     Length
+		curveType  BezierCurveType
+	}{
+		{
+			//0
+			PtXy(0, 0), PtXy(1, 2), PtXy(2, 0),
+			"QuadraticBezier[ Curve(0t^2+2t+0, -4t^2+4t+0, t, 0, 1) ]",
+			PtXy(0.66, 0.8844), PtXy(1, 1),
+			RectanglePt(PtXy(0, 0), PtXy(2, 1)), 2.957885715089,
+			BEZIER_CURVE_TYPE_PLAIN,
+		}, {
+			PtXy(10, 10), PtXy(40, 50), PtXy(70, -20),
+			"QuadraticBezier[ Curve(0t^2+60t+10, -110t^2+80t+10, t, 0, 1) ]",
+			PtXy(29.8, 24.421), PtXy(40, 22.5),
+			RectanglePt(PtXy(10, -20), PtXy(70, 24.545454545)), 88.590293998642,
+			BEZIER_CURVE_TYPE_PLAIN,
+		}, {
+			PtXy(0, 0), PtXy(3, 0), PtXy(1, 0),
+			"QuadraticBezier[ Curve(-5t^2+6t+0, 0t^2+0t+0, t, 0, 1) ]",
+			PtXy(1.4355, 0), PtXy(1.75, 0),
+			RectanglePt(PtXy(0, 0), PtXy(1.8, 0)), 2.6,
+			BEZIER_CURVE_TYPE_CUSP,
+		},
+	}
+	for h, test := range identityTests {
+		a := QuadraticBezierPt(test.p1, test.p2, test.p3)
+		if s := a.String(); s != test.s {
+			t.Errorf("[%d](%s).String() failed. %s != %s",
+				h, a, s, test.s)
+		}
+		if p := a.Begin(); !IsEqualPair(p, test.p1) {
+			t.Errorf("[%d](%s).Begin() failed. %v != %v",
+				h, a, p, test.p1)
+		}
+		if p := a.PtAtT(0.33); !IsEqualPair(p, test.p33) {
+			t.Errorf("[%d](%s).PtAtT(0.33) failed. %v != %v",
+				h, a, p, test.p33)
+		}
+		if p := a.PtAtT(0.50); !IsEqualPair(p, test.p50) {
+			t.Errorf("[%d](%s).PtAtT(0.50) failed. %v != %v",
+				h, a, p, test.p50)
+		}
+		if p := a.End(); !IsEqualPair(p, test.p3) {
+			t.Errorf("[%d](%s).End() failed. %v != %v",
+				h, a, p, test.p3)
+		}
+		if box := a.BoundingBox(); !IsEqualPts(box, test.box) {
+			t.Errorf("[%d](%s).BoundingBox() failed. %v != %v",
+				h, a, box, test.box)
+		}
+		if length := a.Length(); !IsEqual(length, test.length) {
+			t.Errorf("[%d](%s).Length() failed. %f != %f",
+				h, a, length, test.length)
+		}
+		if ct := a.CurveType(); ct != test.curveType {
+			t.Errorf("[%d](%s).CurveType() failed. %d != %d",
+				h, a, ct, test.curveType)
+		}
+
+		// Elevation draws the same curve.
+		e := a.Elevate()
+		for _, tv := range []float64{0, 0.33, 0.5, 0.67, 1} {
+			if p, q := a.PtAtT(tv), e.PtAtT(tv); !IsEqualPair(p, q) {
+				t.Errorf("[%d](%s).Elevate().PtAtT(%f) failed. %v != %v",
+					h, a, tv, q, p)
+			}
+		}
+
+		left, right := a.SplitAtT(0.33)
+		if !IsEqualPair(left.End(), test.p33) || !IsEqualPair(right.Begin(), test.p33) ||
+			!IsEqualPair(left.PtAtT(0.5), a.PtAtT(0.165)) || !IsEqualPair(right.End(), test.p3) {
+			t.Errorf("[%d](%s).SplitAtT(0.33) failed. %v, %v",
+				h, a, left.Points(), right.Points())
+		}
+	}
+
+	tangentTests := []struct {
+		p1, p2, p3 Pt
+		t33, n33   Vector
+	}{
+		{
+			PtXy(0, 0), PtXy(1, 2), PtXy(2, 0),
+			VectorIj(2, 1.36), VectorIj(-1.36, 2),
+		}, {
+			PtXy(10, 10), PtXy(40, 50), PtXy(70, -20),
+			VectorIj(60, 7.4), VectorIj(-7.4, 60),
+		},
+	}
+	for h, test := range tangentTests {
+		a := QuadraticBezierPt(test.p1, test.p2, test.p3)
+		tangent, normal := a.TangentAtT(0.33)
+		if !IsEqualPair(tangent, test.t33) || !IsEqualPair(normal, test.n33) {
+			t.Errorf("[%d](%s).TangentAtT(0.33) failed. %v != %v || %v != %v",
+				h, a, tangent, test.t33, normal, test.n33)
+		}
+	}
+
+	a := QuadraticBezierPt(PtXy(10, 10), PtXy(40, 50), PtXy(70, -20))
+	trans, theta, scale, ax := a.AlignOnX()
+	if !IsEqualPair(trans, VectorIj(-10, -10)) || !IsEqual(theta, -5.819537698) || !IsEqual(scale, 67.082039325) || !IsEqualPair(ax.End(), PtXy(1, 0)) {
+		t.Errorf("(%s).AlignOnX() failed. %v, %v, %v, %v",
+			a, trans, theta, scale, ax.Points())
+	}
+	box := a.TightBox()
+	expected := PolygonPt(PtXy(10, 10), PtXy(70, -20), PtXy(81, 2), PtXy(21, 32))
+	if !IsEqualPts(box, expected) {
+		t.Errorf("(%s).TightBox() failed. %v != %v", a, box, expected)
+	}
+	if fast := a.FastBox(); !IsEqualPts(fast, RectanglePt(PtXy(10, -20), PtXy(70, 50))) {
+		t.Errorf("(%s).FastBox() failed. %v", a, fast)
+	}
+	if pts := a.InflectionPts(); len(pts) != 0 {
+		t.Errorf("(%s).InflectionPts() failed. %v", a, pts)
+	}
+}
+
 func BenchmarkBezierLength(b *testing.B) {
 	lengthTests := []Bezier{
 		BezierPt(PtXy(10, 10), PtXy(10, 40), PtXy(50, 45), PtXy(45, -10)),
@@ -1137,3 +1408,189 @@ func BenchmarkBezierApproxLength(b *testing.B) {
 		lengthTests[h%max].ApproxLength(16)
 	}
 }
+
+func TestClosestPtCurve(t *testing.T) {
+	type closester interface {
+		ClosestPt(Pt) (float64, Pt, Length)
+		PtAtT(float64) Pt
+	}
+	cusp := BezierPt(PtXy(0, 0), PtXy(4, 4), PtXy(0, 4), PtXy(4, 0))
+	if ct := cusp.CurveType(); ct != BEZIER_CURVE_TYPE_CUSP {
+		t.Fatalf("(%v).CurveType() failed. %d != %d", cusp, ct, BEZIER_CURVE_TYPE_CUSP)
+	}
+	quartic := ParamPts(PtXy(-2.42, -8.24), PtXy(-0.14, -2.94), PtXy(5.74, -8.84), PtXy(9.96, 0.4), PtXy(13.78, -5.2))
+
+	closestTests := []struct {
+		c    closester
+		p    Pt
+		t    float64
+		pt   Pt
+		dist Length
+	}{
+		{
+			//0
+			BezierPt(PtXy(10, 10), PtXy(10, 40), PtXy(50, 45), PtXy(45, -10)), PtXy(30, 20),
+			0.657705609693, PtXy(37.726011626, 26.792471131), 10.287318393156,
+		}, {
+			BezierPt(PtXy(10, 10), PtXy(10, 40), PtXy(50, 45), PtXy(45, -10)), PtXy(0, 0),
+			0, PtXy(10, 10), 14.142135623731,
+		}, {
+			cusp, PtXy(2, 4),
+			0.5, PtXy(2, 3), 1,
+		}, {
+			cusp, PtXy(2, 3),
+			0.5, PtXy(2, 3), 0,
+		}, {
+			cusp, PtXy(2, 2),
+			0.76494169472, PtXy(2.297557508, 2.157670781), 0.336749974851,
+		}, {
+			//5
+			quartic, PtXy(5, 0),
+			0.557308605170, PtXy(6.358490034, -4.492569898), 4.693472004819,
+		}, {
+			QuadraticBezierPt(PtXy(0, 0), PtXy(1, 2), PtXy(2, 0)), PtXy(1, 3),
+			0.5, PtXy(1, 1), 2,
+		},
+	}
+	for h, test := range closestTests {
+		tv, pt, dist := test.c.ClosestPt(test.p)
+		if !IsEqual(tv, test.t) || !IsEqualPair(pt, test.pt) || !IsEqual(dist, test.dist) && !IsZero(dist-test.dist) {
+			t.Errorf("[%d](%v).ClosestPt(%v) failed. %f, %v, %f != %f, %v, %f",
+				h, test.c, test.p, tv, pt, dist, test.t, test.pt, test.dist)
+		}
+		// No sampled point is closer.
+		for i := 0; i <= 200; i++ {
+			if d := test.p.VectorTo(test.c.PtAtT(float64(i) / 200)).Magnitude(); d < dist-1e-9 {
+				t.Errorf("[%d][%d](%v).ClosestPt(%v) (sample) failed. %f < %f",
+					h, i, test.c, test.p, d, dist)
+			}
+		}
+	}
+}
+
+func TestCurvature(t *testing.T) {
+	type curvaturer interface {
+		CurvatureAtT(float64) float64
+		MaxCurvatureTs() []float64
+		OsculatingCircleAtT(float64) Circle
+		RadiusOfCurvatureAtT(float64) Length
+	}
+	cubic := BezierPt(PtXy(10, 10), PtXy(10, 40), PtXy(50, 45), PtXy(45, -10))
+	cusp := BezierPt(PtXy(0, 0), PtXy(4, 4), PtXy(0, 4), PtXy(4, 0))
+	line := BezierPt(PtXy(0, 0), PtXy(1, 1), PtXy(2, 2), PtXy(3, 3))
+
+	curvatureTests := []struct {
+		c      curvaturer
+		t      float64
+		k      float64
+		r      Length
+		center Pt
+	}{
+		{
+			//0
+			QuadraticBezierPt(PtXy(0, 0), PtXy(1, 2), PtXy(2, 0)), 0.5,
+			-2, 0.5, PtXy(1, 0.5),
+		}, {
+			QuadraticBezierPt(PtXy(0, 0), PtXy(1, 2), PtXy(2, 0)), 0.25,
+			-0.707106781187, 1.414213562373, PtXy(1.5, -0.25),
+		}, {
+			cubic, 0.474371273660,
+			-0.077502030610, 12.902887732427, PtXy(26.84071167, 19.223331367),
+		}, {
+			ParamCubic(PtXy(10, 10), PtXy(10, 40), PtXy(50, 45), PtXy(45, -10)), 0.25,
+			-0.054634256805, 18.303534421103, PtXy(29.38119854, 14.595457522),
+		}, {
+			cusp, 0.25,
+			0.238513917600, 4.192627457812, PtXy(-2, 4.125),
+		}, {
+			//5
+			ParamPts(PtXy(1, 0), PtXy(1, 1), PtXy(0, 1)), 0.5,
+			1.414213562373, 0.707106781187, PtXy(0.25, 0.25),
+		},
+	}
+	for h, test := range curvatureTests {
+		if k := test.c.CurvatureAtT(test.t); !IsEqual(k, test.k) {
+			t.Errorf("[%d](%v).CurvatureAtT(%f) failed. %f != %f",
+				h, test.c, test.t, k, test.k)
+		}
+		if r := test.c.RadiusOfCurvatureAtT(test.t); !IsEqual(r, test.r) {
+			t.Errorf("[%d](%v).RadiusOfCurvatureAtT(%f) failed. %f != %f",
+				h, test.c, test.t, r, test.r)
+		}
+		circle := test.c.OsculatingCircleAtT(test.t)
+		if !IsEqualPair(circle.Center(), test.center) || !IsEqual(circle.Radius(), test.r) {
+			t.Errorf("[%d](%v).OsculatingCircleAtT(%f) failed. %v != %v, %f",
+				h, test.c, test.t, circle, test.center, test.r)
+		}
+	}
+
+	straightTests := []struct {
+		c curvaturer
+		t float64
+	}{
+		{line, 0.25},
+		{ParamLinear(PtXy(1, 2), PtXy(3, -4)), 0.5},
+	}
+	for h, test := range straightTests {
+		if k := test.c.CurvatureAtT(test.t); k != 0 {
+			t.Errorf("[%d](%v).CurvatureAtT(%f) failed. %f != 0",
+				h, test.c, test.t, k)
+		}
+		if r := test.c.RadiusOfCurvatureAtT(test.t); !math.IsInf(float64(r), 1) {
+			t.Errorf("[%d](%v).RadiusOfCurvatureAtT(%f) failed. %f != +Inf",
+				h, test.c, test.t, r)
+		}
+		if _, err := test.c.OsculatingCircleAtT(test.t).OrErr(); err == nil {
+			t.Errorf("[%d](%v).OsculatingCircleAtT(%f) failed. %v should be in error",
+				h, test.c, test.t, test.c.OsculatingCircleAtT(test.t))
+		}
+	}
+	if k := cusp.CurvatureAtT(0.5); !math.IsNaN(k) {
+		t.Errorf("(%v).CurvatureAtT(0.5) failed. %f != NaN", cusp, k)
+	}
+
+	maxTests := []struct {
+		c  curvaturer
+		ts []float64
+	}{
+		{
+			//0
+			QuadraticBezierPt(PtXy(0, 0), PtXy(1, 2), PtXy(2, 0)),
+			[]float64{0.5},
+		}, {
+			cubic,
+			[]float64{0.474371273660},
+		}, {
+			ParamCubic(PtXy(10, 10), PtXy(10, 40), PtXy(50, 45), PtXy(45, -10)),
+			[]float64{0.474371273660},
+		}, {
+			cusp,
+			[]float64{0.5},
+		}, {
+			line,
+			nil,
+		},
+	}
+	for h, test := range maxTests {
+		ts := test.c.MaxCurvatureTs()
+		if len(ts) != len(test.ts) {
+			t.Errorf("[%d](%v).MaxCurvatureTs() (length) failed. %v != %v",
+				h, test.c, ts, test.ts)
+			continue
+		}
+		for i := range ts {
+			if !IsEqual(ts[i], test.ts[i]) {
+				t.Errorf("[%d][%d](%v).MaxCurvatureTs() failed. %f != %f",
+					h, i, test.c, ts[i], test.ts[i])
+			}
+		}
+	}
+	// No sampled point curves more than the maximum.
+	kmax := math.Abs(cubic.CurvatureAtT(0.474371273660))
+	for i := 0; i <= 200; i++ {
+		if k := math.Abs(cubic.CurvatureAtT(float64(i) / 200)); k > kmax+1e-12 {
+			t.Errorf("[%d](%v).CurvatureAtT(%f) (sample) failed. %f > %f",
+				i, cubic, float64(i)/200, k, kmax)
+		}
+	}
+}
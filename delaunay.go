@@ -0,0 +1,165 @@
+package figuring
+
+import (
+	"sort"
+)
+
+// Delaunay is the Delaunay triangulation of a set of points. No point is
+// inside the circumcircle of any triangle, which avoids long thin triangles
+// as much as the points allow.
+type Delaunay struct {
+	pts       []Pt
+	vertex    []int
+	tris      [][3]int
+	neighbors [][]int
+}
+
+// DelaunayPts creates the Delaunay triangulation of \c pts. Duplicate points
+// share the triangles and neighbors of their first copy. Points in error are
+// left out.
+func DelaunayPts(pts ...Pt) Delaunay {
+	m, vertex := delaunayMesh(pts)
+	d := Delaunay{
+		pts:       m.pts,
+		vertex:    vertex,
+		tris:      m.triangles(),
+		neighbors: make([][]int, len(pts)),
+	}
+
+	seen := make(map[[2]int]bool)
+	link := func(a, b int) {
+		if k := meshKey(a, b); !seen[k] {
+			seen[k] = true
+			d.neighbors[a] = append(d.neighbors[a], b)
+			d.neighbors[b] = append(d.neighbors[b], a)
+		}
+	}
+	if len(d.tris) > 0 {
+		for _, tri := range d.tris {
+			link(tri[0], tri[1])
+			link(tri[1], tri[2])
+			link(tri[2], tri[0])
+		}
+	} else if len(m.verts) > 1 {
+		// Collinear points have no triangles, but each point still
+		// neighbors the points on either side of it.
+		line := append([]int{}, m.verts...)
+		sort.Slice(line, func(i, j int) bool {
+			return byXThenY(d.pts).Less(line[i], line[j])
+		})
+		for h := 1; h < len(line); h++ {
+			link(line[h-1], line[h])
+		}
+	}
+
+	for v, around := range d.neighbors {
+		p := d.pts[v]
+		sort.Slice(around, func(i, j int) bool {
+			return p.VectorTo(d.pts[around[i]]).Angle() < p.VectorTo(d.pts[around[j]]).Angle()
+		})
+	}
+	return d
+}
+
+// Neighbors returns the indexes of the points that share a triangle side with
+// the point at index \c site, in counter-clockwise order. Returns nil for
+// points in error.
+func (d Delaunay) Neighbors(site int) []int {
+	if v := d.vertex[site]; v >= 0 {
+		return d.neighbors[v][:]
+	}
+	return nil
+}
+
+// Points returns the triangulated points.
+func (d Delaunay) Points() []Pt { return d.pts[:] }
+
+// Triangles returns the triangles as counter-clockwise indexes into
+// \c Delaunay.Points. Use \c TrianglesFromIndexes to convert them to
+// polygons.
+func (d Delaunay) Triangles() [][3]int { return d.tris[:] }
+
+// Voronoi is the Voronoi diagram of a set of points, called sites. The cell
+// of a site is the area that is closer to that site than to any other.
+type Voronoi struct {
+	delaunay Delaunay
+	bounds   Rectangle
+	cells    []Polygon
+}
+
+// VoronoiPts creates the Voronoi diagram of \c sites. The cells are clipped
+// to \c bounds, so the cells of sites on the hull are not infinite.
+func VoronoiPts(bounds Rectangle, sites ...Pt) Voronoi {
+	d := DelaunayPts(sites...)
+	vd := Voronoi{
+		delaunay: d,
+		bounds:   bounds,
+		cells:    make([]Polygon, len(sites)),
+	}
+	box := bounds.Normalize().Points()
+	for h, s := range sites {
+		v := d.vertex[h]
+		if v < 0 {
+			vd.cells[h] = PolygonPt()
+			continue
+		}
+		if v != h {
+			vd.cells[h] = vd.cells[v]
+			continue
+		}
+
+		// The cell is the intersection of the half planes closer to the
+		// site than to each neighbor.
+		cell := append([]Pt{}, box...)
+		for _, n := range d.neighbors[v] {
+			away := s.VectorTo(d.pts[n])
+			mid := s.Add(away.Scale(Half))
+			cell = clipHalfPlane(cell, mid, away)
+		}
+		vd.cells[h] = PolygonPt(simplifyRing(cell)...)
+	}
+	return vd
+}
+
+// Bounds returns the rectangle the cells are clipped to.
+func (vd Voronoi) Bounds() Rectangle { return vd.bounds }
+
+// Cell returns the counter-clockwise polygon of the area closest to the site
+// at index \c site. The polygon is empty for sites in error, and for sites
+// whose cell is outside the bounds.
+func (vd Voronoi) Cell(site int) Polygon { return vd.cells[site] }
+
+// Cells returns the cell of every site, in the order of the sites.
+func (vd Voronoi) Cells() []Polygon { return vd.cells[:] }
+
+// Delaunay returns the Delaunay triangulation of the sites, which is the dual
+// of the Voronoi diagram.
+func (vd Voronoi) Delaunay() Delaunay { return vd.delaunay }
+
+// Neighbors returns the indexes of the sites whose cells share a side with
+// the cell of \c site, in counter-clockwise order. Sites whose shared side is
+// outside the bounds are still included.
+func (vd Voronoi) Neighbors(site int) []int { return vd.delaunay.Neighbors(site) }
+
+// Sites returns the points the diagram was created from.
+func (vd Voronoi) Sites() []Pt { return vd.delaunay.Points() }
+
+// clipHalfPlane returns the part of the convex polygon \c pts that is behind
+// the line through \c origin with normal \c normal.
+func clipHalfPlane(pts []Pt, origin Pt, normal Vector) []Pt {
+	// see https://en.wikipedia.org/wiki/Sutherland%E2%80%93Hodgman_algorithm
+	n := len(pts)
+	out := make([]Pt, 0, n+1)
+	for h := 0; h < n; h++ {
+		a, b := pts[h], pts[(h+1)%n]
+		da, db := origin.VectorTo(a).Dot(normal), origin.VectorTo(b).Dot(normal)
+		if da <= 0 {
+			out = append(out, a)
+		}
+		if (da < 0 && db > 0) || (da > 0 && db < 0) {
+			t := da / (da - db)
+			out = append(out, a.Add(a.VectorTo(b).Scale(t)))
+		}
+	}
+	return out
+}
@@ -0,0 +1,173 @@
+package figuring
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDelaunay(t *testing.T) {
+	var grid []Pt
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			grid = append(grid, PtXy(Length(x)+0.5, Length(y)+0.5))
+		}
+	}
+	var ring []Pt
+	for h := 0; h < 100; h++ {
+		theta := 2 * math.Pi * float64(h) / 100
+		ring = append(ring, PtXy(Length(1e6*math.Cos(theta)), Length(1e6*math.Sin(theta))))
+	}
+	scattered := []Pt{
+		PtXy(0, 0), PtXy(10, 0), PtXy(10, 10), PtXy(0, 10), PtXy(3, 4),
+		PtXy(7, 2), PtXy(5, 8), PtXy(1, 6), PtXy(8, 6), PtXy(4, 1),
+	}
+
+	triangleTests := []struct {
+		pts   []Pt
+		count int
+		area  Length
+	}{
+		{
+			//0
+			grid, 8, 4,
+		}, {
+			ring, 98, PolygonPt(ring...).Area(),
+		}, {
+			append(append([]Pt{}, ring...), PtXy(0, 0)), 100, PolygonPt(ring...).Area(),
+		}, {
+			scattered, 14, 100,
+		}, {
+			[]Pt{PtXy(0, 0), PtXy(1, 1), PtXy(2, 2)}, 0, 0,
+		}, {
+			//5
+			[]Pt{PtXy(0, 0), PtXy(1, 0), PtXy(0, 1), PtXy(1, 0), PtNaN}, 1, 0.5,
+		}, {
+			nil, 0, 0,
+		},
+	}
+	for h, test := range triangleTests {
+		d := DelaunayPts(test.pts...)
+		tris := d.Triangles()
+		if len(tris) != test.count {
+			t.Errorf("[%d]DelaunayPts(%v).Triangles() (count) failed. %d != %d",
+				h, test.pts, len(tris), test.count)
+		}
+		var sum Length
+		for _, poly := range TrianglesFromIndexes(d.Points(), tris) {
+			if w := poly.Winding(); w != WINDING_COUNTERCLOCKWISE {
+				t.Errorf("[%d]DelaunayPts(%v).Triangles() (winding) failed. %v != %v. %v",
+					h, test.pts, w, WINDING_COUNTERCLOCKWISE, poly)
+			}
+			sum += poly.Area()
+		}
+		if !IsEqual(sum, test.area) {
+			t.Errorf("[%d]DelaunayPts(%v).Triangles() (area) failed. %f != %f",
+				h, test.pts, sum, test.area)
+		}
+		for _, tri := range tris {
+			pts := d.Points()
+			for i, p := range pts {
+				if _, err := p.OrErr(); err == nil && incirclePts(pts[tri[0]], pts[tri[1]], pts[tri[2]], p) > 0 {
+					t.Errorf("[%d][%d]DelaunayPts(%v).Triangles() failed. %v inside %v",
+						h, i, test.pts, p, tri)
+				}
+			}
+		}
+	}
+
+	neighborTests := []struct {
+		pts       []Pt
+		site      int
+		neighbors []int
+	}{
+		{
+			//0
+			[]Pt{PtXy(0, 0), PtXy(1, 1), PtXy(2, 2)}, 1,
+			[]int{2, 0},
+		}, {
+			[]Pt{PtXy(0, 0), PtXy(1, 1), PtXy(2, 2)}, 0,
+			[]int{1},
+		}, {
+			[]Pt{PtXy(0, 0), PtXy(4, 0), PtXy(2, 3), PtXy(2, 1)}, 3,
+			[]int{2, 0, 1},
+		}, {
+			[]Pt{PtXy(0, 0), PtXy(4, 0), PtXy(2, 3), PtXy(4, 0)}, 3,
+			[]int{2, 0},
+		}, {
+			[]Pt{PtXy(0, 0), PtNaN}, 1,
+			nil,
+		},
+	}
+	for h, test := range neighborTests {
+		neighbors := DelaunayPts(test.pts...).Neighbors(test.site)
+		if len(neighbors) != len(test.neighbors) {
+			t.Errorf("[%d]DelaunayPts(%v).Neighbors(%d) failed. %v != %v",
+				h, test.pts, test.site, neighbors, test.neighbors)
+			continue
+		}
+		for i := range neighbors {
+			if neighbors[i] != test.neighbors[i] {
+				t.Errorf("[%d]DelaunayPts(%v).Neighbors(%d) failed. %v != %v",
+					h, test.pts, test.site, neighbors, test.neighbors)
+				break
+			}
+		}
+	}
+}
+
+func TestVoronoi(t *testing.T) {
+	var grid []Pt
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			grid = append(grid, PtXy(Length(x)+0.5, Length(y)+0.5))
+		}
+	}
+	bounds := RectanglePt(PtXy(0, 0), PtXy(3, 3))
+
+	cellTests := []struct {
+		sites []Pt
+		site  int
+		cell  Polygon
+	}{
+		{
+			//0
+			grid, 4,
+			PolygonPt(PtXy(2, 1), PtXy(2, 2), PtXy(1, 2), PtXy(1, 1)),
+		}, {
+			grid, 0,
+			PolygonPt(PtXy(0, 0), PtXy(1, 0), PtXy(1, 1), PtXy(0, 1)),
+		}, {
+			[]Pt{PtXy(1, 1), PtXy(2, 2)}, 0,
+			PolygonPt(PtXy(0, 0), PtXy(3, 0), PtXy(0, 3)),
+		}, {
+			[]Pt{PtXy(1, 1), PtXy(2, 1), PtXy(1, 1)}, 2,
+			PolygonPt(PtXy(0, 0), PtXy(1.5, 0), PtXy(1.5, 3), PtXy(0, 3)),
+		}, {
+			[]Pt{PtXy(1, 1), PtNaN}, 1,
+			PolygonPt(),
+		},
+	}
+	for h, test := range cellTests {
+		vd := VoronoiPts(bounds, test.sites...)
+		cell := vd.Cell(test.site)
+		if !IsEqualPts(cell, test.cell) {
+			t.Errorf("[%d]VoronoiPts(%v).Cell(%d) failed. %v != %v",
+				h, test.sites, test.site, cell, test.cell)
+		}
+	}
+
+	// The cells cover the bounds without overlapping.
+	vd := VoronoiPts(bounds, grid...)
+	var sum Length
+	for h, cell := range vd.Cells() {
+		if c := cell.ContainsPt(grid[h], FILL_RULE_NON_ZERO); c != CONTAINMENT_INSIDE {
+			t.Errorf("[%d]VoronoiPts(%v).Cells() failed. %v != %v",
+				h, grid, c, CONTAINMENT_INSIDE)
+		}
+		sum += cell.Area()
+	}
+	if area := bounds.Area(); !IsEqual(sum, area) {
+		t.Errorf("VoronoiPts(%v).Cells() (area) failed. %f != %f",
+			grid, sum, area)
+	}
+}
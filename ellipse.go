@@ -0,0 +1,357 @@
+package figuring
+
+import (
+	"fmt"
+	"math"
+)
+
+// Ellipse represents a geometric ellipse defined as a center point, the radius
+// along each axis, and the rotation of the x-axis radius from the X axis.
+//
+// Angles on the ellipse are parametric: the point at \c theta is where the
+// point at \c theta on the unit circle ends up after the circle is scaled by
+// the radii and rotated.
+type Ellipse struct {
+	c        Pt
+	rx, ry   Length
+	rotation Radians
+}
+
+// EllipseCenter creates an ellipse around \c c, with the radii \c rx and \c ry,
+// rotated \c rotation radians.
+func EllipseCenter(c Pt, rx, ry Length, rotation Radians) Ellipse {
+	if rx < 0 {
+		rx = -rx
+	}
+	if ry < 0 {
+		ry = -ry
+	}
+	return Ellipse{
+		c:        c,
+		rx:       rx,
+		ry:       ry,
+		rotation: rotation,
+	}
+}
+
+// Area returns the area of the ellipse.
+func (e Ellipse) Area() Length { return Length(math.Pi) * e.rx * e.ry }
+
+// BoundingBox returns an axis-aligned rectangle that encompasses the ellipse.
+func (e Ellipse) BoundingBox() Rectangle {
+	// see https://iquilezles.org/articles/ellipses/
+	sin, cos := math.Sincos(float64(e.rotation))
+	rx, ry := float64(e.rx), float64(e.ry)
+	w := Length(math.Sqrt(rx*rx*cos*cos + ry*ry*sin*sin))
+	h := Length(math.Sqrt(rx*rx*sin*sin + ry*ry*cos*cos))
+	v := VectorIj(w, h)
+	return RectanglePt(e.c.Add(v), e.c.Add(v.Invert()))
+}
+
+// Center returns the center of the ellipse.
+func (e Ellipse) Center() Pt { return e.c }
+
+// ContainsPt returns if \c p is inside, outside, or on the boundary of the
+// ellipse.
+func (e Ellipse) ContainsPt(p Pt) Containment {
+	d := e.toUnit(e.c.VectorTo(p)).Magnitude()
+	switch {
+	case IsEqual(d, 1):
+		return CONTAINMENT_BOUNDARY
+	case d < 1:
+		return CONTAINMENT_INSIDE
+	}
+	return CONTAINMENT_OUTSIDE
+}
+
+// Length returns the distance around the ellipse.
+func (e Ellipse) Length() Length { return e.lengthBetween(0, 2*math.Pi) }
+
+// OrErr returns a floating point error if the center, radii, or rotation are
+// in error.
+func (e Ellipse) OrErr() (Ellipse, *FloatingPointError) {
+	if _, err := e.c.OrErr(); err != nil {
+		return e, err
+	} else if _, err := e.rx.OrErr(); err != nil {
+		return e, err
+	} else if _, err := e.ry.OrErr(); err != nil {
+		return e, err
+	} else if _, err := e.rotation.OrErr(); err != nil {
+		return e, err
+	}
+	return e, nil
+}
+
+// PtAtTheta returns the point on the ellipse at the parametric angle
+// \c theta.
+func (e Ellipse) PtAtTheta(theta Radians) Pt {
+	sin, cos := math.Sincos(float64(theta))
+	v := VectorIj(e.rx*Length(cos), e.ry*Length(sin))
+	return e.c.Add(v.Rotate(e.rotation))
+}
+
+// Radii returns the radius along the x-axis and the y-axis of the ellipse,
+// before rotation.
+func (e Ellipse) Radii() (Length, Length) { return e.rx, e.ry }
+
+// Rotation returns the angle between the X axis and the x-axis radius.
+func (e Ellipse) Rotation() Radians { return e.rotation }
+
+// String returns a string representation of the ellipse.
+func (e Ellipse) String() string {
+	return fmt.Sprintf("Ellipse(%v, %s, %s, %v)",
+		e.c,
+		HumanFormat(9, e.rx),
+		HumanFormat(9, e.ry),
+		e.rotation,
+	)
+}
+
+// TangentAtTheta returns the tangent and the normal of the ellipse at the
+// parametric angle \c theta.
+func (e Ellipse) TangentAtTheta(theta Radians) (Vector, Vector) {
+	sin, cos := math.Sincos(float64(theta))
+	tangent := VectorIj(-e.rx*Length(sin), e.ry*Length(cos)).Rotate(e.rotation)
+	ti, tj := tangent.Units()
+	normal := VectorIj(-tj, ti)
+	return tangent, normal
+}
+
+// lengthBetween returns the distance along the ellipse between two parametric
+// angles.
+func (e Ellipse) lengthBetween(from, to Radians) Length {
+	if to < from {
+		from, to = to, from
+	}
+	rx, ry := float64(e.rx), float64(e.ry)
+	speed := func(theta float64) float64 {
+		sin, cos := math.Sincos(theta)
+		return math.Hypot(rx*sin, ry*cos)
+	}
+
+	// Integrate a quarter turn at a time, so the speed changes smoothly
+	// within each piece.
+	pieces := int(math.Ceil(float64(to-from) / (math.Pi / 2)))
+	if pieces < 1 {
+		pieces = 1
+	}
+	step := float64(to-from) / float64(pieces)
+	var sum float64
+	for h := 0; h < pieces; h++ {
+		lo := float64(from) + step*float64(h)
+		sum += legendreGaussIntegrate(speed, lo, lo+step)
+	}
+	return Length(sum)
+}
+
+// toUnit converts \c v into the space where the ellipse is the unit circle.
+func (e Ellipse) toUnit(v Vector) Vector {
+	i, j := v.Rotate(-e.rotation).Units()
+	return VectorIj(i/e.rx, j/e.ry)
+}
+
+// thetaAtPt returns the parametric angle of the point \c p.
+func (e Ellipse) thetaAtPt(p Pt) Radians {
+	return e.toUnit(e.c.VectorTo(p)).Angle()
+}
+
+// EllipticalArc represents a part of an ellipse. The arc starts at the
+// parametric angle \c start and continues for \c sweep radians. Positive
+// sweeps are counter-clockwise and negative sweeps are clockwise.
+type EllipticalArc struct {
+	e     Ellipse
+	start Radians
+	sweep Radians
+}
+
+// EllipticalArcCenter creates an arc of the ellipse \c e, starting at the
+// parametric angle \c start and sweeping \c sweep radians. Sweeps beyond a
+// full turn are limited to a full turn.
+func EllipticalArcCenter(e Ellipse, start, sweep Radians) EllipticalArc {
+	return EllipticalArc{
+		e:     e,
+		start: start,
+		sweep: Clamp(-2*math.Pi, sweep, 2*math.Pi),
+	}
+}
+
+// EllipticalArcFromSVG creates an arc from the endpoint parameters of an SVG
+// \c A path command. The arc goes from \c begin to \c end on an ellipse with
+// the radii \c rx and \c ry, rotated by \c rotation. \c largeArc picks the arc
+// that sweeps more than half a turn, and \c sweep picks the arc that goes in
+// the direction of increasing angles.
+//
+// Radii that are too small to reach between the points are scaled up, as SVG
+// requires. SVG treats zero radii as a straight segment; those arcs are in
+// error, and should be replaced with a \c Segment by the caller.
+func EllipticalArcFromSVG(begin Pt, rx, ry Length, rotation Radians, largeArc, sweep bool, end Pt) EllipticalArc {
+	// see https://www.w3.org/TR/SVG11/implnote.html#ArcConversionEndpointToCenter
+	if rx < 0 {
+		rx = -rx
+	}
+	if ry < 0 {
+		ry = -ry
+	}
+	if IsZero(rx) || IsZero(ry) {
+		e := EllipseCenter(PtNaN, rx, ry, rotation)
+		return EllipticalArcCenter(e, Radians(math.NaN()), Radians(math.NaN()))
+	}
+	if IsEqualPair(begin, end) {
+		e := EllipseCenter(begin, rx, ry, rotation)
+		return EllipticalArcCenter(e, 0, 0)
+	}
+
+	// Step 1: move the midpoint of the endpoints to the origin, and undo
+	// the rotation.
+	half := end.VectorTo(begin).Scale(Half).Rotate(-rotation)
+	x1, y1 := half.Units()
+
+	// Correct radii that are too small.
+	lambda := (x1*x1)/(rx*rx) + (y1*y1)/(ry*ry)
+	if lambda > 1 {
+		scale := Length(math.Sqrt(float64(lambda)))
+		rx, ry = rx*scale, ry*scale
+	}
+
+	// Step 2: find the center in that space.
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+	coef := Length(math.Sqrt(math.Max(0, float64(num/den))))
+	if largeArc == sweep {
+		coef = -coef
+	}
+	cx, cy := coef*rx*y1/ry, -coef*ry*x1/rx
+
+	// Step 3: move the center back.
+	mid := begin.Add(begin.VectorTo(end).Scale(Half))
+	center := mid.Add(VectorIj(cx, cy).Rotate(rotation))
+
+	// Step 4: find the angles.
+	u := VectorIj((x1-cx)/rx, (y1-cy)/ry)
+	v := VectorIj((-x1-cx)/rx, (-y1-cy)/ry)
+	start := u.Angle()
+	delta := (v.Angle() - start).Normalize()
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	}
+	return EllipticalArcCenter(EllipseCenter(center, rx, ry, rotation), start, delta)
+}
+
+// AppendFlatten appends the points of \c Flatten to \c dst, and returns the
+// extended slice. It doesn't allocate when \c dst has enough capacity.
+func (ea EllipticalArc) AppendFlatten(dst []Pt, tolerance Length) []Pt {
+	if _, err := ea.OrErr(); err != nil || !(tolerance > 0) {
+		return dst
+	}
+	// The arc is a circular arc stretched by the radii, which stretches the
+	// distance to each chord by no more than the larger radius.
+	steps := arcFlattenSteps(Maximum(ea.e.rx, ea.e.ry), ea.sweep, tolerance)
+	dst = append(dst, ea.Begin())
+	for h := 1; h < steps; h++ {
+		dst = append(dst, ea.PtAtT(float64(h)/float64(steps)))
+	}
+	return append(dst, ea.End())
+}
+
+// Begin returns the first point of the arc.
+func (ea EllipticalArc) Begin() Pt { return ea.PtAtT(0) }
+
+// BoundingBox returns an axis-aligned rectangle that encompasses all the
+// points of the arc.
+func (ea EllipticalArc) BoundingBox() Rectangle {
+	// The extremes are where the tangent is horizontal or vertical.
+	sin, cos := math.Sincos(float64(ea.e.rotation))
+	rx, ry := float64(ea.e.rx), float64(ea.e.ry)
+	thetaX := Radians(math.Atan2(-ry*sin, rx*cos))
+	thetaY := Radians(math.Atan2(ry*cos, rx*sin))
+
+	pts := []Pt{ea.Begin(), ea.End()}
+	for _, theta := range []Radians{thetaX, thetaX + math.Pi, thetaY, thetaY + math.Pi} {
+		if _, ok := sweepT(ea.start, ea.sweep, theta); ok {
+			pts = append(pts, ea.e.PtAtTheta(theta))
+		}
+	}
+	lx, mx, ly, my := LimitsPts(pts)
+	return RectanglePt(PtXy(lx, ly), PtXy(mx, my))
+}
+
+// Ellipse returns the ellipse the arc is part of.
+func (ea EllipticalArc) Ellipse() Ellipse { return ea.e }
+
+// End returns the last point of the arc.
+func (ea EllipticalArc) End() Pt { return ea.PtAtT(1) }
+
+// Flatten returns the points of a polyline that is no more than
+// \c tolerance from any point of the arc. The chords are equal steps of
+// parametric angle. See \c SegmentsFromPts. The tolerance must be greater
+// than zero.
+func (ea EllipticalArc) Flatten(tolerance Length) []Pt { return ea.AppendFlatten(nil, tolerance) }
+
+// Length returns the distance along the arc.
+func (ea EllipticalArc) Length() Length {
+	return ea.e.lengthBetween(ea.start, ea.start+ea.sweep)
+}
+
+// OrErr returns a floating point error if the ellipse or the angles are in
+// error.
+func (ea EllipticalArc) OrErr() (EllipticalArc, *FloatingPointError) {
+	if _, err := ea.e.OrErr(); err != nil {
+		return ea, err
+	} else if _, err := ea.start.OrErr(); err != nil {
+		return ea, err
+	} else if _, err := ea.sweep.OrErr(); err != nil {
+		return ea, err
+	}
+	return ea, nil
+}
+
+// PtAtT returns the point for the provided value of \c t. \c t goes from 0 at
+// \c Begin to 1 at \c End, in equal steps of parametric angle rather than
+// equal steps of length.
+func (ea EllipticalArc) PtAtT(t float64) Pt {
+	t = Clamp(0, t, 1)
+	return ea.e.PtAtTheta(ea.start + ea.sweep*Radians(t))
+}
+
+// SplitAtT splits the arc into two arcs at \c t. See \c PtAtT.
+func (ea EllipticalArc) SplitAtT(t float64) (EllipticalArc, EllipticalArc) {
+	t = Clamp(0, t, 1)
+	first := ea.sweep * Radians(t)
+	return EllipticalArcCenter(ea.e, ea.start, first),
+		EllipticalArcCenter(ea.e, ea.start+first, ea.sweep-first)
+}
+
+// Start returns the parametric angle of \c Begin.
+func (ea EllipticalArc) Start() Radians { return ea.start }
+
+// String returns a string representation of the arc.
+func (ea EllipticalArc) String() string {
+	return fmt.Sprintf("EllipticalArc(%v, %v, %v)", ea.e, ea.start, ea.sweep)
+}
+
+// Sweep returns the parametric angle covered by the arc. Positive values are
+// counter-clockwise and negative values are clockwise.
+func (ea EllipticalArc) Sweep() Radians { return ea.sweep }
+
+// TangentAtT returns the tangent and the normal of the arc for the given
+// value of \c t. See \c PtAtT.
+func (ea EllipticalArc) TangentAtT(t float64) (Vector, Vector) {
+	t = Clamp(0, t, 1)
+	tangent, _ := ea.e.TangentAtTheta(ea.start + ea.sweep*Radians(t))
+	tangent = tangent.Scale(Length(ea.sweep))
+	ti, tj := tangent.Units()
+	normal := VectorIj(-tj, ti)
+	return tangent, normal
+}
+
+// FilterPtsEllipticalArc returns the points of \c pts that are at a
+// parametric angle covered by the arc. The distance from the center is not
+// checked.
+func FilterPtsEllipticalArc(ea EllipticalArc, pts []Pt) (ret []Pt) {
+	for _, p := range pts {
+		if _, ok := sweepT(ea.start, ea.sweep, ea.e.thetaAtPt(p)); ok {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
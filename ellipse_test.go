@@ -0,0 +1,262 @@
+package figuring
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEllipse(t *testing.T) {
+	wide := EllipseCenter(PtXy(0, 0), 2, 1, 0)
+
+	identityTests := []struct {
+		a      Ellipse
+		pt     Pt
+		box    Rectangle
+		length Length
+	}{
+		{
+			//0
+			wide, PtXy(0, 1),
+			RectanglePt(PtXy(-2, -1), PtXy(2, 1)), 9.688448220547675,
+		}, {
+			EllipseCenter(PtXy(1, 1), 2, 1, math.Pi/2), PtXy(0, 1),
+			RectanglePt(PtXy(0, -1), PtXy(2, 3)), 9.688448220547675,
+		}, {
+			EllipseCenter(PtXy(0, 0), 2, 1, math.Pi/4), PtXy(-0.707106781187, 0.707106781187),
+			RectanglePt(PtXy(-1.58113883008, -1.58113883008), PtXy(1.58113883008, 1.58113883008)), 9.688448220547675,
+		}, {
+			EllipseCenter(PtXy(0, 0), 1, 1, 0), PtXy(0, 1),
+			RectanglePt(PtXy(-1, -1), PtXy(1, 1)), 2 * Length(math.Pi),
+		}, {
+			EllipseCenter(PtXy(0, 0), 5, 3, 0), PtXy(0, 3),
+			RectanglePt(PtXy(-5, -3), PtXy(5, 3)), 25.526998863398,
+		},
+	}
+	for h, test := range identityTests {
+		a := test.a
+		if p := a.PtAtTheta(math.Pi / 2); !IsEqualPair(p, test.pt) {
+			t.Errorf("[%d](%v).PtAtTheta() failed. %v != %v",
+				h, a, p, test.pt)
+		}
+		if box := a.BoundingBox(); !IsEqualPair(box.MinPt(), test.box.MinPt()) || !IsEqualPair(box.MaxPt(), test.box.MaxPt()) {
+			t.Errorf("[%d](%v).BoundingBox() failed. %v != %v",
+				h, a, box, test.box)
+		}
+		if length := a.Length(); !IsEqual(length, test.length) {
+			t.Errorf("[%d](%v).Length() failed. %f != %f",
+				h, a, length, test.length)
+		}
+	}
+
+	containsTests := []struct {
+		a Ellipse
+		p Pt
+		c Containment
+	}{
+		{wide, PtXy(2, 0), CONTAINMENT_BOUNDARY},
+		{wide, PtXy(1.5, 0.5), CONTAINMENT_INSIDE},
+		{wide, PtXy(0, 1.5), CONTAINMENT_OUTSIDE},
+		{EllipseCenter(PtXy(0, 0), 2, 1, math.Pi/2), PtXy(0, 1.5), CONTAINMENT_INSIDE},
+	}
+	for h, test := range containsTests {
+		a := test.a
+		if c := a.ContainsPt(test.p); c != test.c {
+			t.Errorf("[%d](%v).ContainsPt(%v) failed. %d != %d",
+				h, a, test.p, c, test.c)
+		}
+	}
+}
+
+func TestEllipticalArc(t *testing.T) {
+	svgTests := []struct {
+		begin           Pt
+		rx, ry          Length
+		rotation        Radians
+		largeArc, sweep bool
+		end             Pt
+		center, mid     Pt
+	}{
+		{
+			//0
+			PtXy(0, 0), 1, 1, 0, false, true, PtXy(2, 0),
+			PtXy(1, 0), PtXy(1, -1),
+		}, {
+			PtXy(0, 0), 1, 1, 0, false, false, PtXy(2, 0),
+			PtXy(1, 0), PtXy(1, 1),
+		}, {
+			PtXy(0, 0), 1, 1, 0, false, true, PtXy(1, 1),
+			PtXy(0, 1), PtXy(0.707106781187, 0.292893218813),
+		}, {
+			PtXy(0, 0), 1, 1, 0, true, true, PtXy(1, 1),
+			PtXy(1, 0), PtXy(1.707106781187, -0.707106781187),
+		}, {
+			PtXy(0, 0), 1, 1, 0, false, true, PtXy(4, 0),
+			PtXy(2, 0), PtXy(2, -2),
+		}, {
+			//5
+			PtXy(0, 0), 2, 1, math.Pi / 2, false, true, PtXy(0, 4),
+			PtXy(0, 2), PtXy(1, 2),
+		},
+	}
+	for h, test := range svgTests {
+		ea := EllipticalArcFromSVG(test.begin, test.rx, test.ry, test.rotation, test.largeArc, test.sweep, test.end)
+		if c := ea.Ellipse().Center(); !IsEqualPair(c, test.center) {
+			t.Errorf("[%d]EllipticalArcFromSVG().Ellipse().Center() failed. %v != %v. %v",
+				h, c, test.center, ea)
+		}
+		if begin := ea.Begin(); !IsEqualPair(begin, test.begin) {
+			t.Errorf("[%d]EllipticalArcFromSVG().Begin() failed. %v != %v. %v",
+				h, begin, test.begin, ea)
+		}
+		if end := ea.End(); !IsEqualPair(end, test.end) {
+			t.Errorf("[%d]EllipticalArcFromSVG().End() failed. %v != %v. %v",
+				h, end, test.end, ea)
+		}
+		if mid := ea.PtAtT(0.5); !IsEqualPair(mid, test.mid) {
+			t.Errorf("[%d]EllipticalArcFromSVG().PtAtT(0.5) failed. %v != %v. %v",
+				h, mid, test.mid, ea)
+		}
+	}
+
+	// Rotated ellipses still hit both endpoints, and the large arc flag picks
+	// the long way around.
+	for h, flags := range [][2]bool{{false, false}, {false, true}, {true, false}, {true, true}} {
+		begin, end := PtXy(1, 2), PtXy(5, 3)
+		ea := EllipticalArcFromSVG(begin, 3, 1.5, RadiansFromDegrees(30), flags[0], flags[1], end)
+		if !IsEqualPair(ea.Begin(), begin) || !IsEqualPair(ea.End(), end) {
+			t.Errorf("[%d]EllipticalArcFromSVG(%v) failed. %v, %v != %v, %v",
+				h, flags, ea.Begin(), ea.End(), begin, end)
+		}
+		if large := math.Abs(float64(ea.Sweep())) > math.Pi; large != flags[0] {
+			t.Errorf("[%d]EllipticalArcFromSVG(%v).Sweep() failed. %v", h, flags, ea.Sweep())
+		}
+		if positive := ea.Sweep() > 0; positive != flags[1] {
+			t.Errorf("[%d]EllipticalArcFromSVG(%v).Sweep() (direction) failed. %v", h, flags, ea.Sweep())
+		}
+	}
+
+	if _, err := EllipticalArcFromSVG(PtXy(0, 0), 0, 1, 0, false, true, PtXy(1, 1)).OrErr(); err == nil {
+		t.Errorf("EllipticalArcFromSVG() with zero radius failed. should be in error")
+	}
+
+	upper := EllipticalArcCenter(EllipseCenter(PtXy(0, 0), 2, 1, 0), 0, math.Pi)
+	if length := upper.Length(); !IsEqual(length, 9.688448220547675/2) {
+		t.Errorf("(%v).Length() failed. %f != %f", upper, length, 9.688448220547675/2)
+	}
+	if box := upper.BoundingBox(); !IsEqualPair(box.MinPt(), PtXy(-2, 0)) || !IsEqualPair(box.MaxPt(), PtXy(2, 1)) {
+		t.Errorf("(%v).BoundingBox() failed. %v", upper, box)
+	}
+	first, second := upper.SplitAtT(0.5)
+	if !IsEqualPair(first.End(), PtXy(0, 1)) || !IsEqualPair(second.Begin(), PtXy(0, 1)) {
+		t.Errorf("(%v).SplitAtT(0.5) failed. %v, %v", upper, first, second)
+	}
+}
+
+func TestIntersectionEllipse(t *testing.T) {
+	wide := EllipseCenter(PtXy(0, 0), 2, 1, 0)
+	upper := EllipticalArcCenter(wide, 0, math.Pi)
+	checkPts := func(name string, h int, a, b interface{}, pts, expected []Pt) {
+		if len(pts) != len(expected) {
+			t.Errorf("[%d]%s(%v, %v) (length) failed. %v != %v",
+				h, name, a, b, pts, expected)
+			return
+		}
+		for i := 0; i < len(pts); i++ {
+			if !IsEqualPair(pts[i], expected[i]) {
+				t.Errorf("[%d][%d]%s(%v, %v) failed. %v != %v",
+					h, i, name, a, b, pts[i], expected[i])
+			}
+		}
+	}
+
+	ellipseLineTests := []struct {
+		a   Ellipse
+		b   Line
+		pts []Pt
+	}{
+		{
+			//0
+			wide, LineXAxis,
+			[]Pt{PtXy(-2, 0), PtXy(2, 0)},
+		}, {
+			wide, LineFromPt(PtXy(-5, 1), PtXy(5, 1)),
+			[]Pt{PtXy(0, 1)},
+		}, {
+			wide, LineFromPt(PtXy(-5, 2), PtXy(5, 2)),
+			nil,
+		}, {
+			EllipseCenter(PtXy(0, 0), 2, 1, math.Pi/2), LineXAxis,
+			[]Pt{PtXy(-1, 0), PtXy(1, 0)},
+		}, {
+			wide, LineFromPt(PtXy(0, 0), PtXy(2, 1)),
+			[]Pt{PtXy(-1.414213562373, -0.707106781187), PtXy(1.414213562373, 0.707106781187)},
+		},
+	}
+	for h, test := range ellipseLineTests {
+		checkPts("IntersectionEllipseLine", h, test.a, test.b,
+			IntersectionEllipseLine(test.a, test.b), test.pts)
+	}
+
+	ellipseSegmentTests := []struct {
+		a   Ellipse
+		b   Segment
+		pts []Pt
+	}{
+		{
+			//0
+			wide, SegmentPt(PtXy(0, 0), PtXy(3, 0)),
+			[]Pt{PtXy(2, 0)},
+		}, {
+			wide, SegmentPt(PtXy(-1, 0), PtXy(1, 0)),
+			nil,
+		}, {
+			wide, SegmentPt(PtXy(-2, 0), PtXy(2, 0)),
+			[]Pt{PtXy(-2, 0), PtXy(2, 0)},
+		},
+	}
+	for h, test := range ellipseSegmentTests {
+		checkPts("IntersectionEllipseSegment", h, test.a, test.b,
+			IntersectionEllipseSegment(test.a, test.b), test.pts)
+	}
+
+	arcLineTests := []struct {
+		a   EllipticalArc
+		b   Line
+		pts []Pt
+	}{
+		{
+			//0
+			upper, LineYAxis,
+			[]Pt{PtXy(0, 1)},
+		}, {
+			upper, LineXAxis,
+			[]Pt{PtXy(-2, 0), PtXy(2, 0)},
+		}, {
+			upper, LineFromPt(PtXy(-5, -0.5), PtXy(5, -0.5)),
+			nil,
+		},
+	}
+	for h, test := range arcLineTests {
+		checkPts("IntersectionEllipticalArcLine", h, test.a, test.b,
+			IntersectionEllipticalArcLine(test.a, test.b), test.pts)
+	}
+
+	arcSegmentTests := []struct {
+		a   EllipticalArc
+		b   Segment
+		pts []Pt
+	}{
+		{
+			//0
+			upper, SegmentPt(PtXy(0, -2), PtXy(0, 2)),
+			[]Pt{PtXy(0, 1)},
+		}, {
+			upper, SegmentPt(PtXy(0, -2), PtXy(0, 0.5)),
+			nil,
+		},
+	}
+	for h, test := range arcSegmentTests {
+		checkPts("IntersectionEllipticalArcSegment", h, test.a, test.b,
+			IntersectionEllipticalArcSegment(test.a, test.b), test.pts)
+	}
+}
@@ -28,6 +28,21 @@ type Derivable interface {
 	Derivative() Polynomial
 }
 
+// Integrable represents a polynomial that can have an antiderivative F(t)
+// generated from it. The constant of integration is zero.
+type Integrable interface {
+	Polynomial
+	Integral() Polynomial
+}
+
+// DefiniteIntegral returns the integral of \c eq from \c min to \c max, the
+// same range as \c ParamCurve. The result is negative when \c min is
+// greater than \c max.
+func DefiniteIntegral(eq Integrable, min, max float64) float64 {
+	antideriv := eq.Integral()
+	return antideriv.AtT(max) - antideriv.AtT(min)
+}
+
 // Constant is a polynomial in the form of f(t) = a
 type Constant struct {
 	a float64
@@ -42,6 +57,8 @@ func (co Constant) Coefficients() []float64 { return []float64{co.a} }
 func (co Constant) AtT(float64) float64     { return co.a }
 func (co Constant) Roots() []float64        { return nil }
 func (co Constant) Derivative() Polynomial  { return ConstantA(0) }
+func (co Constant) Integral() Polynomial    { return co.Antiderivative() }
+func (co Constant) Antiderivative() Linear  { return LinearAb(co.a, 0) }
 func (co Constant) A() float64              { return co.a }
 func (co Constant) String() string          { return co.Text('t', true) }
 func (co Constant) Text(unknown rune, addPrefix bool) string {
@@ -84,6 +101,8 @@ func (le Linear) Roots() []float64 {
 }
 func (le Linear) Derivative() Polynomial    { return le.FirstDerivative() }
 func (le Linear) FirstDerivative() Constant { return ConstantA(le.ab[0]) }
+func (le Linear) Integral() Polynomial      { return le.Antiderivative() }
+func (le Linear) Antiderivative() Quadratic { return QuadraticAbc(le.ab[0]/2, le.ab[1], 0) }
 func (le Linear) Ab() (float64, float64)    { return le.ab[0], le.ab[1] }
 func (le Linear) String() string            { return le.Text('t', true) }
 func (le Linear) Text(unknown rune, addPrefix bool) string {
@@ -144,8 +163,13 @@ func (qad Quadratic) Roots() []float64 {
 	g := math.Sqrt(D) / (2 * a)
 	return []float64{f + g, f - g}
 }
-func (qad Quadratic) Derivative() Polynomial           { return qad.FirstDerivative() }
-func (qad Quadratic) FirstDerivative() Linear          { return LinearAb(2*qad.abc[0], qad.abc[1]) }
+func (qad Quadratic) Derivative() Polynomial  { return qad.FirstDerivative() }
+func (qad Quadratic) FirstDerivative() Linear { return LinearAb(2*qad.abc[0], qad.abc[1]) }
+func (qad Quadratic) Integral() Polynomial    { return qad.Antiderivative() }
+func (qad Quadratic) Antiderivative() Cubic {
+	a, b, c := qad.Abc()
+	return CubicAbcd(a/3, b/2, c, 0)
+}
 func (qad Quadratic) Abc() (float64, float64, float64) { return qad.abc[0], qad.abc[1], qad.abc[2] }
 func (qad Quadratic) String() string                   { return qad.Text('t', true) }
 func (qad Quadratic) Text(unknown rune, addPrefix bool) string {
@@ -246,6 +270,11 @@ func (cub Cubic) FirstDerivative() Quadratic {
 	a, b, c, _ := cub.Abcd()
 	return QuadraticAbc(3*a, 2*b, c)
 }
+func (cub Cubic) Integral() Polynomial { return cub.Antiderivative() }
+func (cub Cubic) Antiderivative() Quartic {
+	a, b, c, d := cub.Abcd()
+	return QuarticAbcde(a/4, b/3, c/2, d, 0)
+}
 func (cub Cubic) Abcd() (float64, float64, float64, float64) {
 	return cub.abcd[0], cub.abcd[1], cub.abcd[2], cub.abcd[3]
 }
@@ -433,6 +462,11 @@ func (qrt Quartic) FirstDerivative() Cubic {
 	a, b, c, d, _ := qrt.Abcde()
 	return CubicAbcd(4*a, 3*b, 2*c, d)
 }
+func (qrt Quartic) Integral() Polynomial { return qrt.Antiderivative() }
+func (qrt Quartic) Antiderivative() PolynomialN {
+	a, b, c, d, e := qrt.Abcde()
+	return PolynomialNCoefficients(a/5, b/4, c/3, d/2, e, 0)
+}
 func (qrt Quartic) Abcde() (float64, float64, float64, float64, float64) {
 	return qrt.abcde[0], qrt.abcde[1], qrt.abcde[2], qrt.abcde[3], qrt.abcde[4]
 }
@@ -493,3 +527,45 @@ func IsEqualEquations[T Coefficienter](a, b T) bool {
 	}
 	return true
 }
+
+// polynomialRoots returns the real roots of the polynomial with the
+// coefficients \c coeffs, highest power first, between \c lo and \c hi. The
+// roots are sorted, and repeated roots are only returned once. See
+// \c PolynomialN.RealRoots.
+func polynomialRoots(coeffs []float64, lo, hi float64) []float64 {
+	var roots []float64
+	for _, root := range PolynomialNCoefficients(coeffs...).RealRoots(lo, hi) {
+		roots = append(roots, root.T)
+	}
+	return roots
+}
+
+// polynomialAdd returns the sum of two polynomials. Coefficients are ordered
+// from the highest degree to the constant.
+func polynomialAdd(a, b []float64) []float64 {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	sum := make([]float64, len(a))
+	copy(sum, a)
+	offset := len(a) - len(b)
+	for h, c := range b {
+		sum[offset+h] += c
+	}
+	return sum
+}
+
+// polynomialMul returns the product of two polynomials. Coefficients are
+// ordered from the highest degree to the constant.
+func polynomialMul(a, b []float64) []float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	product := make([]float64, len(a)+len(b)-1)
+	for i, ca := range a {
+		for j, cb := range b {
+			product[i+j] += ca * cb
+		}
+	}
+	return product
+}
@@ -509,3 +509,56 @@ func TestQuarticPolynomial(t *testing.T) {
 		}
 	}
 }
+
+func TestIntegrable(t *testing.T) {
+	pn := PolynomialNCoefficients
+	tests := []struct {
+		eq        Integrable
+		antideriv []float64
+		min, max  float64
+		integral  float64
+	}{
+		{ConstantA(3), []float64{3, 0}, 0, 2, 6},
+		{LinearAb(2, 1), []float64{1, 1, 0}, 0, 1, 2},
+		{QuadraticAbc(3, 0, -1), []float64{1, 0, -1, 0}, -1, 2, 6},
+		{CubicAbcd(4, 3, 2, 1), []float64{1, 1, 1, 1, 0}, 0, 1, 4},
+		{QuarticAbcde(5, 0, 0, 0, 1), []float64{1, 0, 0, 0, 1, 0}, 1, 0, -2},
+		{pn(6, 0, 0, 0, 0, 2), []float64{1, 0, 0, 0, 0, 2, 0}, 0, 1, 3},
+		{pn(), []float64{0}, 0, 1, 0},
+	}
+	for h, test := range tests {
+		antideriv, ok := test.eq.Integral().(Coefficienter)
+		if !ok {
+			t.Errorf("[%d](%v).Integral() failed. %T isn't a Coefficienter",
+				h, test.eq, test.eq.Integral())
+		} else if !IsEqualEquations(PolynomialNFrom(antideriv), pn(test.antideriv...)) {
+			t.Errorf("[%d](%v).Integral() failed. %v != %v",
+				h, test.eq, antideriv, test.antideriv)
+		}
+		// The derivative of the antiderivative is the original.
+		if d, ok := test.eq.Integral().(Derivable); ok {
+			if got, want := d.Derivative().AtT(0.7), test.eq.AtT(0.7); !IsEqual(got, want) {
+				t.Errorf("[%d](%v).Integral().Derivative() failed. %f != %f",
+					h, test.eq, got, want)
+			}
+		}
+		if integral := DefiniteIntegral(test.eq, test.min, test.max); !IsEqual(integral, test.integral) {
+			t.Errorf("[%d]DefiniteIntegral(%v, %f, %f) failed. %f != %f",
+				h, test.eq, test.min, test.max, integral, test.integral)
+		}
+	}
+
+	// The Bernstein form matches the power basis.
+	cubic := CubicAbcd(-1, 3, 13, 2)
+	bernstein := BernsteinFrom(cubic)
+	if a, e := bernstein.Antiderivative().PolynomialN(), PolynomialNFrom(cubic.Antiderivative()); !IsEqualEquations(a, e) {
+		t.Errorf("(%v).Antiderivative() failed. %v != %v", bernstein, a, e)
+	}
+	if a, e := DefiniteIntegral(bernstein, 0, 1), DefiniteIntegral(cubic, 0, 1); !IsEqual(a, e) {
+		t.Errorf("DefiniteIntegral(%v, 0, 1) failed. %f != %f", bernstein, a, e)
+	}
+	// The area under a curve of control values is their mean.
+	if a := DefiniteIntegral(BernsteinCoefficients(1, 2, 6), 0, 1); !IsEqual(a, 3) {
+		t.Errorf("DefiniteIntegral(%v, 0, 1) failed. %f != 3", BernsteinCoefficients(1, 2, 6), a)
+	}
+}
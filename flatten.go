@@ -0,0 +1,176 @@
+package figuring
+
+import (
+	"math"
+)
+
+const (
+	// flattenMaxDepth limits how many times a curve is halved while
+	// flattening.
+	flattenMaxDepth = 16
+	// flattenMaxDegree is the largest degree of polynomial that is flattened
+	// using the hull of its control points. Larger degrees are sampled.
+	flattenMaxDegree = 15
+)
+
+// SegmentsFromPts returns the segments that connect each point to the next,
+// such as the points from \c Bezier.Flatten.
+func SegmentsFromPts(pts []Pt) []Segment { return AppendSegmentsFromPts(nil, pts) }
+
+// AppendSegmentsFromPts appends the segments that connect each point to the
+// next to \c dst, and returns the extended slice. It doesn't allocate when
+// \c dst has enough capacity.
+func AppendSegmentsFromPts(dst []Segment, pts []Pt) []Segment {
+	for h := 1; h < len(pts); h++ {
+		dst = append(dst, SegmentPt(pts[h-1], pts[h]))
+	}
+	return dst
+}
+
+// flatDistance returns the distance between \c p and the chord from \c begin
+// to \c end.
+func flatDistance(begin, end, p Pt) Length {
+	_, _, d := SegmentPt(begin, end).ClosestPt(p)
+	return d
+}
+
+// bernsteinPts holds the coordinates of the control points of a curve while
+// flattening, so that halving the curve doesn't allocate.
+type bernsteinPts [flattenMaxDegree + 1]float64
+
+// appendFlatPts appends the points of the curve with the control points
+// \c pts to \c dst. The first point is not appended.
+func appendFlatPts(dst []Pt, pts []Pt, tolerance Length) []Pt {
+	var xs, ys bernsteinPts
+	for h, p := range pts {
+		xs[h], ys[h] = float64(p.X()), float64(p.Y())
+	}
+	return appendFlatBernstein(dst, &xs, &ys, len(pts)-1, tolerance, 0)
+}
+
+// appendFlatBernstein appends the end points of the chords that are within
+// tolerance of the curve of degree \c n with the control points \c xs and
+// \c ys. The curve is inside the hull of its control points, so the farthest
+// control point bounds the distance to the chord.
+func appendFlatBernstein(dst []Pt, xs, ys *bernsteinPts, n int, tolerance Length, depth int) []Pt {
+	begin, end := PtXy(Length(xs[0]), Length(ys[0])), PtXy(Length(xs[n]), Length(ys[n]))
+	var d Length
+	for h := 1; h < n; h++ {
+		d = Maximum(d, flatDistance(begin, end, PtXy(Length(xs[h]), Length(ys[h]))))
+	}
+	if d <= tolerance || depth >= flattenMaxDepth {
+		return append(dst, end)
+	}
+
+	// see https://pomax.github.io/bezierinfo/#splitting
+	var lx, ly bernsteinPts
+	rx, ry := *xs, *ys
+	for k := 0; k <= n; k++ {
+		lx[k], ly[k] = rx[0], ry[0]
+		for j := 0; j < n-k; j++ {
+			rx[j], ry[j] = (rx[j]+rx[j+1])/2, (ry[j]+ry[j+1])/2
+		}
+	}
+	dst = appendFlatBernstein(dst, &lx, &ly, n, tolerance, depth+1)
+	return appendFlatBernstein(dst, &rx, &ry, n, tolerance, depth+1)
+}
+
+// appendFlatParamCurve appends the points of the curve to \c dst. The first
+// point is not appended. Polynomials with coefficients are converted to the
+// control points of the same curve, and other curves are sampled.
+func appendFlatParamCurve(dst []Pt, pc ParamCurve, tolerance Length) []Pt {
+	var xs, ys bernsteinPts
+	if n, ok := bernsteinOnInterval(pc.X, pc.Y, pc.Min, pc.Max, &xs, &ys); ok {
+		dst = appendFlatBernstein(dst, &xs, &ys, n, tolerance, 0)
+		// Use the exact end rather than the converted one.
+		dst[len(dst)-1] = pc.End()
+		return dst
+	}
+	return appendFlatSampled(dst, pc.PtAtT, pc.Min, pc.Max, tolerance, 0)
+}
+
+// appendFlatSampled appends the end points of the chords that are within
+// tolerance of the curve between \c lo and \c hi, measured at a few points
+// along each chord.
+func appendFlatSampled(dst []Pt, ptAtT func(float64) Pt, lo, hi float64, tolerance Length, depth int) []Pt {
+	const samples = 8
+	begin, end := ptAtT(lo), ptAtT(hi)
+	var d Length
+	for h := 1; h < samples; h++ {
+		d = Maximum(d, flatDistance(begin, end, ptAtT(lo+(hi-lo)*float64(h)/samples)))
+	}
+	if d <= tolerance || depth >= flattenMaxDepth {
+		return append(dst, end)
+	}
+	mid := lo + (hi-lo)/2
+	dst = appendFlatSampled(dst, ptAtT, lo, mid, tolerance, depth+1)
+	return appendFlatSampled(dst, ptAtT, mid, hi, tolerance, depth+1)
+}
+
+// bernsteinOnInterval converts the polynomials between \c lo and \c hi into
+// the control points of a Bezier curve of the same degree, stored in \c xs
+// and \c ys. Returns the degree, and false if the polynomials don't provide
+// coefficients or have too high of a degree.
+func bernsteinOnInterval(x, y Polynomial, lo, hi float64, xs, ys *bernsteinPts) (int, bool) {
+	cx, ok := x.(Coefficienter)
+	if !ok {
+		return 0, false
+	}
+	cy, ok := y.(Coefficienter)
+	if !ok {
+		return 0, false
+	}
+	px, py := cx.Coefficients(), cy.Coefficients()
+	n := len(px) - 1
+	if len(py)-1 > n {
+		n = len(py) - 1
+	}
+	if n > flattenMaxDegree || n < 0 {
+		return 0, false
+	}
+	for _, pair := range []struct {
+		coeffs []float64
+		out    *bernsteinPts
+	}{{px, xs}, {py, ys}} {
+		// Coefficients are highest degree first, so pad the front.
+		var c bernsteinPts
+		copy(c[n+1-len(pair.coeffs):], pair.coeffs)
+
+		// Shift to p(lo + u), then scale to p(lo + (hi-lo)u).
+		// see https://en.wikipedia.org/wiki/Horner%27s_method#Polynomial_evaluation_and_long_division
+		for k := 0; k < n; k++ {
+			for j := 1; j <= n-k; j++ {
+				c[j] += lo * c[j-1]
+			}
+		}
+		scale := 1.0
+		for i := 0; i <= n; i++ {
+			c[n-i] *= scale
+			scale *= hi - lo
+		}
+
+		// see https://en.wikipedia.org/wiki/Bernstein_polynomial#Transformation_of_the_power_basis
+		for k := 0; k <= n; k++ {
+			var sum float64
+			ratio := 1.0 // binomial(k, i) / binomial(n, i)
+			for i := 0; i <= k; i++ {
+				sum += ratio * c[n-i]
+				ratio *= float64(k-i) / float64(n-i)
+			}
+			pair.out[k] = sum
+		}
+	}
+	return n, true
+}
+
+// arcFlattenSteps returns the number of equal chords needed to keep every
+// chord within \c tolerance of a circular arc with radius \c r and
+// \c sweep. No chord spans more than a quarter circle.
+func arcFlattenSteps(r Length, sweep Radians, tolerance Length) int {
+	// The distance between a chord and the arc is r * (1 - cos(step/2)).
+	step := math.Pi / 2
+	if 0 < tolerance && tolerance < r {
+		step = math.Min(step, 2*math.Acos(float64(1-tolerance/r)))
+	}
+	return int(math.Max(1, math.Ceil(math.Abs(float64(sweep))/step)))
+}
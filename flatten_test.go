@@ -0,0 +1,120 @@
+package figuring
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFlatten(t *testing.T) {
+	type flattener interface {
+		AppendFlatten([]Pt, Length) []Pt
+		Begin() Pt
+		End() Pt
+		Flatten(Length) []Pt
+		PtAtT(float64) Pt
+	}
+	cubic := BezierPt(PtXy(10, 10), PtXy(10, 40), PtXy(50, 45), PtXy(45, -10))
+
+	flattenTests := []struct {
+		a         flattener
+		tolerance Length
+		pts       int
+	}{
+		{
+			//0
+			cubic, 0.1,
+			33,
+		}, {
+			cubic, 0.001,
+			257,
+		}, {
+			ParamCubic(PtXy(10, 10), PtXy(10, 40), PtXy(50, 45), PtXy(45, -10)), 0.1,
+			33,
+		}, {
+			BezierPt(PtXy(0, 0), PtXy(1, 1), PtXy(2, 2), PtXy(3, 3)), 0.001,
+			2,
+		}, {
+			QuadraticBezierPt(PtXy(0, 0), PtXy(1, 2), PtXy(2, 0)), 0.01,
+			17,
+		}, {
+			//5
+			ParamPts(PtXy(-2.42, -8.24), PtXy(-0.14, -2.94), PtXy(5.74, -8.84), PtXy(9.96, 0.4), PtXy(13.78, -5.2)), 0.01,
+			37,
+		}, {
+			CirclePt(PtXy(1, 2), 10), 0.01,
+			72,
+		}, {
+			ArcCenter(PtOrig, 10, 0, -math.Pi/2), 0.01,
+			19,
+		}, {
+			ArcCenter(PtOrig, 10, 1, 0.01), 0.01,
+			2,
+		}, {
+			EllipticalArcCenter(EllipseCenter(PtOrig, 10, 5, 0.3), 0, 3), 0.01,
+			35,
+		},
+	}
+	for h, test := range flattenTests {
+		a, tolerance := test.a, test.tolerance
+		pts := a.Flatten(tolerance)
+		if len(pts) != test.pts {
+			t.Errorf("[%d](%v).Flatten(%f) (length) failed. %d != %d",
+				h, a, tolerance, len(pts), test.pts)
+		}
+		if len(pts) < 2 {
+			continue
+		}
+		if !IsEqualPair(pts[0], a.Begin()) || !IsEqualPair(pts[len(pts)-1], a.End()) {
+			t.Errorf("[%d](%v).Flatten(%f) (ends) failed. %v, %v != %v, %v",
+				h, a, tolerance, pts[0], pts[len(pts)-1], a.Begin(), a.End())
+		}
+		// Every point of the curve is within tolerance of the polyline.
+		segments := SegmentsFromPts(pts)
+		for i := 0; i <= 500; i++ {
+			p := a.PtAtT(float64(i) / 500)
+			best := Length(math.Inf(1))
+			for _, s := range segments {
+				_, _, d := s.ClosestPt(p)
+				best = Minimum(best, d)
+			}
+			if best > tolerance*(1+1e-9) {
+				t.Errorf("[%d][%d](%v).Flatten(%f) (distance) failed. %v %f > %f",
+					h, i, a, tolerance, p, best, tolerance)
+			}
+		}
+
+		dst := append(make([]Pt, 0, len(pts)+1), PtOrig)
+		appended := a.AppendFlatten(dst, tolerance)
+		if len(appended) != len(pts)+1 || &appended[0] != &dst[0] || appended[0] != PtOrig {
+			t.Errorf("[%d](%v).AppendFlatten(%f) failed. %v", h, a, tolerance, appended)
+		}
+	}
+
+	dst := make([]Pt, 0, 300)
+	for h, a := range []flattener{cubic, QuadraticBezierPt(PtXy(0, 0), PtXy(1, 2), PtXy(2, 0)), CirclePt(PtOrig, 10)} {
+		if allocs := testing.AllocsPerRun(10, func() { dst = a.AppendFlatten(dst[:0], 0.001) }); allocs != 0 {
+			t.Errorf("[%d](%v).AppendFlatten() (allocations) failed. %f != 0", h, a, allocs)
+		}
+	}
+
+	for h, tolerance := range []Length{0, -1, Length(math.NaN())} {
+		if pts := cubic.Flatten(tolerance); pts != nil {
+			t.Errorf("[%d](%v).Flatten(%f) failed. %v != nil", h, cubic, tolerance, pts)
+		}
+	}
+
+	pts := []Pt{PtXy(0, 0), PtXy(1, 0), PtXy(1, 1)}
+	segments := SegmentsFromPts(pts)
+	expected := []Segment{SegmentPt(PtXy(0, 0), PtXy(1, 0)), SegmentPt(PtXy(1, 0), PtXy(1, 1))}
+	if len(segments) != len(expected) {
+		t.Fatalf("SegmentsFromPts(%v) (length) failed. %d != %d", pts, len(segments), len(expected))
+	}
+	for h := range segments {
+		if !IsEqualPts(segments[h], expected[h]) {
+			t.Errorf("[%d]SegmentsFromPts(%v) failed. %v != %v", h, pts, segments[h], expected[h])
+		}
+	}
+	if segments := SegmentsFromPts(pts[:1]); segments != nil {
+		t.Errorf("SegmentsFromPts(%v) failed. %v != nil", pts[:1], segments)
+	}
+}
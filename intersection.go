@@ -106,6 +106,30 @@ func IntersectionLineBezier(a Line, b Bezier) []Pt {
 	return roots
 }
 
+// IntersectionLineQuadraticBezier returns the intersection points of a line
+// and a quadratic bezier. Returns an empty slice if the two do not intersect.
+func IntersectionLineQuadraticBezier(a Line, b QuadraticBezier) []Pt {
+	bb := b.BoundingBox()
+	grossIntersections := IntersectionRectangleLine(bb, a)
+	if len(grossIntersections) == 0 {
+		return nil
+	}
+
+	var pts []Pt = RotateOrTranslateToXAxis(a, b.Points())
+
+	// At this point, the line is now the X axis. Find the roots of the curve.
+	b2 := QuadraticBezierPt(pts[0], pts[1], pts[2])
+	yr := b2.y.Roots()
+	roots := make([]Pt, 0, len(yr))
+	for h := 0; h < len(yr); h++ {
+		if 0 <= yr[h] && yr[h] <= 1.0 {
+			roots = append(roots, b.PtAtT(yr[h]))
+		}
+	}
+
+	return uniquePts(roots)
+}
+
 // IntersectionRayRay returns the intersection points of two rays
 // Returns an empty slice if the two do not intersect.
 func IntersectionRayRay(a Ray, b Ray) []Pt {
@@ -183,6 +207,25 @@ func IntersectionSegmentBezier(a Segment, b Bezier) []Pt {
 	return points
 }
 
+// IntersectionSegmentQuadraticBezier returns the intersection points of a
+// segment and a quadratic bezier. Returns an empty slice if the two do not
+// intersect.
+func IntersectionSegmentQuadraticBezier(a Segment, b QuadraticBezier) []Pt {
+	aLine := LineFromPt(a.Begin(), a.End())
+	potentialPoints := IntersectionLineQuadraticBezier(aLine, b)
+	if len(potentialPoints) == 0 {
+		return nil
+	}
+
+	points := make([]Pt, 0, len(potentialPoints))
+	for _, p := range potentialPoints {
+		if isPtOnSegment(p, a) {
+			points = append(points, p)
+		}
+	}
+	return uniquePts(points)
+}
+
 // --- Rectangle Dominant Intersections ---
 
 func IntersectionRectangleLine(a Rectangle, b Line) []Pt {
@@ -294,19 +337,19 @@ func IntersectionPolygonSegment(a Polygon, b Segment) []Pt {
 	for _, aside := range sides {
 		ptset = append(ptset, IntersectionSegmentSegment(aside, b)...)
 	}
-	if len(ptset) == 0 {
-		return nil
-	}
 
-	SortPts(ptset)
-	pts := make([]Pt, 1, len(ptset))
-	pts[0] = ptset[0]
-	for h := 1; h < len(ptset); h++ {
-		if !IsEqualPair(pts[len(pts)-1], ptset[h]) {
-			pts = append(pts, ptset[h])
-		}
+	return uniquePts(ptset)
+}
+
+// IntersectionPolygonQuadraticBezier returns the intersection points of the
+// sides of a polygon and a quadratic bezier. Returns an empty slice if the
+// two do not intersect.
+func IntersectionPolygonQuadraticBezier(a Polygon, b QuadraticBezier) []Pt {
+	var pts []Pt
+	for _, side := range a.Sides() {
+		pts = append(pts, IntersectionSegmentQuadraticBezier(side, b)...)
 	}
-	return pts
+	return uniquePts(pts)
 }
 
 // --- Bezier Dominant Intersections ---
@@ -370,3 +413,420 @@ func IntersectionBezierBezier(a, b Bezier) []Pt {
 	SortPts(ret)
 	return ret
 }
+
+// --- Quadratic Bezier Dominant Intersections ---
+
+// IntersectionQuadraticBezierQuadraticBezier returns the intersection points
+// of two quadratic beziers. Returns an empty slice if the two do not
+// intersect.
+func IntersectionQuadraticBezierQuadraticBezier(a, b QuadraticBezier) []Pt {
+	if len(IntersectionRectangleRectangle(a.BoundingBox(), b.BoundingBox())) == 0 {
+		return nil
+	}
+	if _, ok := quadraticBezierArea(a); !ok {
+		return IntersectionSegmentQuadraticBezier(quadraticBezierSpan(a), b)
+	}
+	return quadraticBezierImplicitPts(a, b.x.Coefficients(), b.y.Coefficients(), b.PtAtT)
+}
+
+// IntersectionQuadraticBezierBezier returns the intersection points of a
+// quadratic bezier and a cubic bezier. Returns an empty slice if the two do
+// not intersect.
+func IntersectionQuadraticBezierBezier(a QuadraticBezier, b Bezier) []Pt {
+	if len(IntersectionRectangleRectangle(a.BoundingBox(), b.BoundingBox())) == 0 {
+		return nil
+	}
+	if _, ok := quadraticBezierArea(a); !ok {
+		span := quadraticBezierSpan(a)
+		var pts []Pt
+		for _, p := range IntersectionLineBezier(LineFromPt(span.Begin(), span.End()), b) {
+			if isPtOnSegment(p, span) {
+				pts = append(pts, p)
+			}
+		}
+		return uniquePts(pts)
+	}
+	return quadraticBezierImplicitPts(a, b.x.Coefficients(), b.y.Coefficients(), b.PtAtT)
+}
+
+// quadraticBezierArea returns twice the signed area of the triangle formed by
+// the points of the curve. Returns false if the points are on a line.
+func quadraticBezierArea(q QuadraticBezier) (Length, bool) {
+	p0, p1, p2 := q.pts[0], q.pts[1], q.pts[2]
+	area := crossPts(p0, p1, p2)
+	v1, v2 := p0.VectorTo(p1), p1.VectorTo(p2)
+	size := v1.Dot(v1) + v2.Dot(v2)
+	if IsZero(size) || IsZero(area/size) {
+		return area, false
+	}
+	return area, true
+}
+
+// quadraticBezierSpan returns the segment covered by a curve whose points are
+// on a line.
+func quadraticBezierSpan(q QuadraticBezier) Segment {
+	ts := []float64{0, 1}
+	ts = append(ts, q.x.FirstDerivative().Roots()...)
+	ts = append(ts, q.y.FirstDerivative().Roots()...)
+	var span Segment
+	best := Length(-1)
+	for h := 0; h < len(ts); h++ {
+		for i := h + 1; i < len(ts); i++ {
+			a, b := q.PtAtT(Clamp(0, ts[h], 1)), q.PtAtT(Clamp(0, ts[i], 1))
+			if d := a.VectorTo(b).Magnitude(); d > best {
+				span, best = SegmentPt(a, b), d
+			}
+		}
+	}
+	return span
+}
+
+// quadraticBezierImplicitPts returns the points where the parametric curve
+// with coefficients \c xs and \c ys crosses the quadratic bezier \c q.
+// The curve is evaluated with \c ptAtT, for t between 0 and 1.
+func quadraticBezierImplicitPts(q QuadraticBezier, xs, ys []float64, ptAtT func(float64) Pt) []Pt {
+	// see https://pomax.github.io/bezierinfo/#intersections
+	// In barycentric coordinates (u, v, w) of the control points, the points
+	// of the curve are ((1-t)^2, 2t(1-t), t^2), so v^2 = 4uw. Each coordinate
+	// is a linear function of x and y, so substituting the other curve gives
+	// a polynomial in its t.
+	p0, p1, p2 := q.pts[0], q.pts[1], q.pts[2]
+	area, _ := quadraticBezierArea(q)
+	barycentric := func(b, c Pt) []float64 {
+		ka := float64(b.Y() - c.Y())
+		kb := float64(c.X() - b.X())
+		kc := float64(b.X()*c.Y() - b.Y()*c.X())
+		return polynomialAdd(
+			polynomialAdd(polynomialMul([]float64{ka}, xs), polynomialMul([]float64{kb}, ys)),
+			[]float64{kc},
+		)
+	}
+	u, v, w := barycentric(p1, p2), barycentric(p2, p0), barycentric(p0, p1)
+	coeffs := polynomialAdd(polynomialMul(v, v), polynomialMul([]float64{-4}, polynomialMul(u, w)))
+
+	var pts []Pt
+	for _, t := range polynomialRoots(coeffs, 0, 1) {
+		p := ptAtT(t)
+		// The implicit curve is the whole parabola. Points on the curve
+		// between 0 and 1 have a positive middle coordinate.
+		if mid := crossPts(p, p2, p0) / area; mid >= 0 || IsZero(mid) {
+			pts = append(pts, p)
+		}
+	}
+	return uniquePts(pts)
+}
+
+// --- Circle Dominant Intersections ---
+
+// IntersectionCircleLine returns the intersection points of a circle and a
+// line. Returns a single point if the line is tangent to the circle, and an
+// empty slice if the two do not intersect.
+func IntersectionCircleLine(a Circle, b Line) []Pt {
+	if b.IsUnknown() {
+		return nil
+	}
+	// ax+by+c=0, so the closest point to the origin is -c(a,b).
+	nb := b.NormalizeUnit()
+	la, lb, lc := nb.Abc()
+	origin := PtXy(-lc*la, -lc*lb)
+	dir := nb.Vector()
+
+	var pts []Pt
+	for _, t := range circleLineParams(a, origin, dir) {
+		pts = append(pts, origin.Add(dir.Scale(t)))
+	}
+	return uniquePts(pts)
+}
+
+// IntersectionCircleSegment returns the intersection points of a circle and a
+// segment. Returns an empty slice if the two do not intersect.
+func IntersectionCircleSegment(a Circle, b Segment) []Pt {
+	length := b.Length()
+	if IsZero(length) {
+		if a.ContainsPt(b.Begin()) == CONTAINMENT_BOUNDARY {
+			return []Pt{b.Begin()}
+		}
+		return nil
+	}
+	dir := b.Begin().VectorTo(b.End()).Normalize()
+
+	var pts []Pt
+	for _, t := range circleLineParams(a, b.Begin(), dir) {
+		switch {
+		case IsEqual(t, 0) || IsZero(t):
+			pts = append(pts, b.Begin())
+		case IsEqual(t, length):
+			pts = append(pts, b.End())
+		case 0 < t && t < length:
+			pts = append(pts, b.Begin().Add(dir.Scale(t)))
+		}
+	}
+	return uniquePts(pts)
+}
+
+// IntersectionCircleRay returns the intersection points of a circle and a
+// ray. Returns an empty slice if the two do not intersect.
+func IntersectionCircleRay(a Circle, b Ray) []Pt {
+	var pts []Pt
+	for _, t := range circleLineParams(a, b.Begin(), b.Vector()) {
+		switch {
+		case IsEqual(t, 0) || IsZero(t):
+			pts = append(pts, b.Begin())
+		case 0 < t:
+			pts = append(pts, b.Begin().Add(b.Vector().Scale(t)))
+		}
+	}
+	return uniquePts(pts)
+}
+
+// IntersectionCircleCircle returns the intersection points of two circles.
+// Returns a single point if the circles are tangent. Returns an empty slice if
+// the circles do not intersect, or if they are the same circle.
+func IntersectionCircleCircle(a, b Circle) []Pt {
+	// see https://paulbourke.net/geometry/circlesphere/
+	v := a.c.VectorTo(b.c)
+	d := v.Magnitude()
+	if IsZero(d) || IsEqualPair(a.c, b.c) {
+		// Concentric circles either never meet, or are the same circle.
+		return nil
+	}
+	outer, inner := a.r+b.r, a.r-b.r
+	if inner < 0 {
+		inner = -inner
+	}
+	tangent := IsEqual(d, outer) || IsEqual(d, inner)
+	if !tangent && (d > outer || d < inner) {
+		return nil
+	}
+
+	// Distance from the center of a to the chord between the points.
+	along := (d*d + a.r*a.r - b.r*b.r) / (2 * d)
+	u := v.Normalize()
+	mid := a.c.Add(u.Scale(along))
+	h2 := a.r*a.r - along*along
+	if tangent || h2 <= 0 {
+		return []Pt{mid}
+	}
+	ui, uj := u.Units()
+	offset := VectorIj(-uj, ui).Scale(Length(math.Sqrt(float64(h2))))
+	return uniquePts([]Pt{mid.Add(offset), mid.Add(offset.Invert())})
+}
+
+// IntersectionCircleBezier returns the intersection points of a circle and a
+// bezier. Returns an empty slice if the two do not intersect.
+func IntersectionCircleBezier(a Circle, b Bezier) []Pt {
+	box := a.BoundingBox()
+	if len(IntersectionRectangleRectangle(box, b.BoundingBox())) == 0 {
+		return nil
+	}
+
+	// Points on the curve are on the circle when
+	// (x(t)-cx)^2 + (y(t)-cy)^2 - r^2 = 0, a degree 6 polynomial.
+	square := func(cub Cubic, center Length) [7]float64 {
+		c := cub.Coefficients()
+		p := [4]float64{c[0], c[1], c[2], c[3] - float64(center)}
+		var sq [7]float64
+		for i := 0; i < 4; i++ {
+			for j := 0; j < 4; j++ {
+				sq[i+j] += p[i] * p[j]
+			}
+		}
+		return sq
+	}
+	cx, cy := a.c.XY()
+	sx, sy := square(b.x, cx), square(b.y, cy)
+	coeffs := make([]float64, 7)
+	for h := range coeffs {
+		coeffs[h] = sx[h] + sy[h]
+	}
+	coeffs[6] -= float64(a.r * a.r)
+
+	var pts []Pt
+	for _, t := range polynomialRoots(coeffs, 0, 1) {
+		pts = append(pts, b.PtAtT(t))
+	}
+	return uniquePts(pts)
+}
+
+// IntersectionCircleQuadraticBezier returns the intersection points of a
+// circle and a quadratic bezier. Returns an empty slice if the two do not
+// intersect.
+func IntersectionCircleQuadraticBezier(a Circle, b QuadraticBezier) []Pt {
+	if len(IntersectionRectangleRectangle(a.BoundingBox(), b.BoundingBox())) == 0 {
+		return nil
+	}
+
+	// Points on the curve are on the circle when
+	// (x(t)-cx)^2 + (y(t)-cy)^2 - r^2 = 0, a degree 4 polynomial.
+	cx, cy := a.c.XY()
+	x := polynomialAdd(b.x.Coefficients(), []float64{-float64(cx)})
+	y := polynomialAdd(b.y.Coefficients(), []float64{-float64(cy)})
+	coeffs := polynomialAdd(polynomialAdd(polynomialMul(x, x), polynomialMul(y, y)), []float64{-float64(a.r * a.r)})
+
+	var pts []Pt
+	for _, t := range polynomialRoots(coeffs, 0, 1) {
+		pts = append(pts, b.PtAtT(t))
+	}
+	return uniquePts(pts)
+}
+
+// IntersectionCirclePolygon returns the intersection points of a circle and
+// the sides of a polygon. Returns an empty slice if the two do not intersect.
+func IntersectionCirclePolygon(a Circle, b Polygon) []Pt {
+	var pts []Pt
+	for _, side := range b.Sides() {
+		pts = append(pts, IntersectionCircleSegment(a, side)...)
+	}
+	return uniquePts(pts)
+}
+
+// circleLineParams returns the distances along the unit vector \c dir, from
+// \c origin, where the line crosses the circle. Returns a single distance if
+// the line is tangent to the circle.
+func circleLineParams(c Circle, origin Pt, dir Vector) []Length {
+	t0 := origin.VectorTo(c.c).Dot(dir)
+	foot := origin.Add(dir.Scale(t0))
+	d := foot.VectorTo(c.c).Magnitude()
+	switch {
+	case IsEqual(d, c.r):
+		return []Length{t0}
+	case d > c.r:
+		return nil
+	}
+	h := Length(math.Sqrt(float64(c.r*c.r - d*d)))
+	return []Length{t0 - h, t0 + h}
+}
+
+// uniquePts sorts \c pts and removes the points that are equal to the point
+// before them. Returns nil if there are no points.
+func uniquePts(pts []Pt) []Pt {
+	if len(pts) == 0 {
+		return nil
+	}
+	SortPts(pts)
+	unique := pts[:1]
+	for _, p := range pts[1:] {
+		if !IsEqualPair(unique[len(unique)-1], p) {
+			unique = append(unique, p)
+		}
+	}
+	return unique
+}
+
+// --- Arc Dominant Intersections ---
+
+// IntersectionArcLine returns the intersection points of an arc and a line.
+// Returns an empty slice if the two do not intersect.
+func IntersectionArcLine(a Arc, b Line) []Pt {
+	return uniquePts(FilterPtsArc(a, IntersectionCircleLine(a.Circle(), b)))
+}
+
+// IntersectionArcSegment returns the intersection points of an arc and a
+// segment. Returns an empty slice if the two do not intersect.
+func IntersectionArcSegment(a Arc, b Segment) []Pt {
+	return uniquePts(FilterPtsArc(a, IntersectionCircleSegment(a.Circle(), b)))
+}
+
+// IntersectionArcCircle returns the intersection points of an arc and a
+// circle. Returns an empty slice if the two do not intersect, or if the arc is
+// part of the circle.
+func IntersectionArcCircle(a Arc, b Circle) []Pt {
+	return uniquePts(FilterPtsArc(a, IntersectionCircleCircle(a.Circle(), b)))
+}
+
+// IntersectionArcArc returns the intersection points of two arcs. Returns an
+// empty slice if the two do not intersect, or if they are part of the same
+// circle.
+func IntersectionArcArc(a, b Arc) []Pt {
+	return uniquePts(FilterPtsArc(a, FilterPtsArc(b, IntersectionCircleCircle(a.Circle(), b.Circle()))))
+}
+
+// IntersectionArcBezier returns the intersection points of an arc and a
+// bezier. Returns an empty slice if the two do not intersect.
+func IntersectionArcBezier(a Arc, b Bezier) []Pt {
+	return uniquePts(FilterPtsArc(a, IntersectionCircleBezier(a.Circle(), b)))
+}
+
+// --- Ellipse Dominant Intersections ---
+
+// IntersectionEllipseLine returns the intersection points of an ellipse and a
+// line. Returns a single point if the line is tangent to the ellipse, and an
+// empty slice if the two do not intersect.
+func IntersectionEllipseLine(a Ellipse, b Line) []Pt {
+	if b.IsUnknown() {
+		return nil
+	}
+	nb := b.NormalizeUnit()
+	la, lb, lc := nb.Abc()
+	origin := PtXy(-lc*la, -lc*lb)
+	dir := nb.Vector()
+
+	var pts []Pt
+	for _, t := range ellipseLineParams(a, origin, dir) {
+		pts = append(pts, origin.Add(dir.Scale(t)))
+	}
+	return uniquePts(pts)
+}
+
+// IntersectionEllipseSegment returns the intersection points of an ellipse
+// and a segment. Returns an empty slice if the two do not intersect.
+func IntersectionEllipseSegment(a Ellipse, b Segment) []Pt {
+	dir := b.Begin().VectorTo(b.End())
+	if IsZero(dir.Magnitude()) {
+		if a.ContainsPt(b.Begin()) == CONTAINMENT_BOUNDARY {
+			return []Pt{b.Begin()}
+		}
+		return nil
+	}
+
+	var pts []Pt
+	for _, t := range ellipseLineParams(a, b.Begin(), dir) {
+		switch {
+		case IsEqual(t, 0) || IsZero(t):
+			pts = append(pts, b.Begin())
+		case IsEqual(t, 1):
+			pts = append(pts, b.End())
+		case 0 < t && t < 1:
+			pts = append(pts, b.Begin().Add(dir.Scale(t)))
+		}
+	}
+	return uniquePts(pts)
+}
+
+// IntersectionEllipticalArcLine returns the intersection points of an
+// elliptical arc and a line. Returns an empty slice if the two do not
+// intersect.
+func IntersectionEllipticalArcLine(a EllipticalArc, b Line) []Pt {
+	return uniquePts(FilterPtsEllipticalArc(a, IntersectionEllipseLine(a.Ellipse(), b)))
+}
+
+// IntersectionEllipticalArcSegment returns the intersection points of an
+// elliptical arc and a segment. Returns an empty slice if the two do not
+// intersect.
+func IntersectionEllipticalArcSegment(a EllipticalArc, b Segment) []Pt {
+	return uniquePts(FilterPtsEllipticalArc(a, IntersectionEllipseSegment(a.Ellipse(), b)))
+}
+
+// ellipseLineParams returns the multiples of \c dir, from \c origin, where the
+// line crosses the ellipse. Returns a single value if the line is tangent to
+// the ellipse.
+func ellipseLineParams(e Ellipse, origin Pt, dir Vector) []Length {
+	// Solve against the unit circle, in the space where the ellipse is the
+	// unit circle. Lines stay lines, and distances along them keep their
+	// proportions.
+	o, d := e.toUnit(e.c.VectorTo(origin)), e.toUnit(dir)
+	a := d.Dot(d)
+	if IsZero(a) {
+		return nil
+	}
+	t0 := -o.Dot(d) / a
+	dist := o.Add(d.Scale(t0)).Magnitude()
+	switch {
+	case IsEqual(dist, 1):
+		return []Length{t0}
+	case dist > 1:
+		return nil
+	}
+	h := Length(math.Sqrt(float64((1 - dist*dist) / a)))
+	return []Length{t0 - h, t0 + h}
+}
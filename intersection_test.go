@@ -236,6 +236,14 @@ func TestIntersectionRectangle(t *testing.T) {
 			RectanglePt(PtXy(1, 1), PtXy(5, 5)),
 			LineFromPt(PtXy(2, 0), PtXy(4, 6)),
 			[]Pt{PtXy(7./3., 1), PtXy(11./3., 5)},
+		}, {
+			RectanglePt(PtXy(1, 1), PtXy(5, 5)),
+			LineFromPt(PtXy(0, 3), PtXy(1, 3)),
+			[]Pt{PtXy(1, 3), PtXy(5, 3)},
+		}, {
+			RectanglePt(PtXy(1, 1), PtXy(5, 5)),
+			LineFromPt(PtXy(0, 6), PtXy(1, 6)),
+			[]Pt{},
 		},
 	}
 	for h, test := range rectangleLineTests {
@@ -268,6 +276,15 @@ func TestIntersectionRectangle(t *testing.T) {
 			RectanglePt(PtXy(1, 1), PtXy(5, 5)),
 			SegmentPt(PtXy(2, 0), PtXy(4, 6)),
 			[]Pt{PtXy(7./3., 1), PtXy(11./3., 5)},
+		}, {
+			// Parallel to the sides.
+			RectanglePt(PtXy(1, 1), PtXy(5, 5)),
+			SegmentPt(PtXy(3, 0), PtXy(3, 4)),
+			[]Pt{PtXy(3, 1)},
+		}, {
+			RectanglePt(PtXy(1, 1), PtXy(5, 5)),
+			SegmentPt(PtXy(0, 6), PtXy(6, 6)),
+			[]Pt{},
 		},
 	}
 	for h, test := range rectangleSegmentTests {
@@ -388,3 +405,355 @@ func BenchmarkIntersectionBezierBezier(b *testing.B) {
 		IntersectionBezierBezier(b1, b2)
 	}
 }
+
+func TestIntersectionCircle(t *testing.T) {
+	unit := CirclePt(PtXy(0, 0), 1)
+	shifted := CirclePt(PtXy(3, 4), 5)
+	checkPts := func(name string, h int, a, b interface{}, pts, expected []Pt) {
+		if len(pts) != len(expected) {
+			t.Errorf("[%d]%s(%v, %v) (length) failed. %v != %v",
+				h, name, a, b, pts, expected)
+			return
+		}
+		for i := 0; i < len(pts); i++ {
+			if !IsEqualPair(pts[i], expected[i]) {
+				t.Errorf("[%d][%d]%s(%v, %v) failed. %v != %v",
+					h, i, name, a, b, pts[i], expected[i])
+			}
+		}
+	}
+
+	circleLineTests := []struct {
+		a   Circle
+		b   Line
+		pts []Pt
+	}{
+		{
+			//0
+			unit, LineXAxis,
+			[]Pt{PtXy(-1, 0), PtXy(1, 0)},
+		}, {
+			unit, LineFromPt(PtXy(-5, 1), PtXy(5, 1)),
+			[]Pt{PtXy(0, 1)},
+		}, {
+			unit, LineFromPt(PtXy(-5, 2), PtXy(5, 2)),
+			nil,
+		}, {
+			shifted, LineYAxis,
+			[]Pt{PtXy(0, 0), PtXy(0, 8)},
+		}, {
+			unit, LineFromPt(PtXy(0, 0), PtXy(1, 1)),
+			[]Pt{PtXy(-0.707106781187, -0.707106781187), PtXy(0.707106781187, 0.707106781187)},
+		}, {
+			//5
+			CirclePt(PtXy(2, 2), 0), LineFromPt(PtXy(0, 0), PtXy(1, 1)),
+			[]Pt{PtXy(2, 2)},
+		}, {
+			unit, LineAbc(0, 0, 1),
+			nil,
+		},
+	}
+	for h, test := range circleLineTests {
+		checkPts("IntersectionCircleLine", h, test.a, test.b,
+			IntersectionCircleLine(test.a, test.b), test.pts)
+	}
+
+	circleSegmentTests := []struct {
+		a   Circle
+		b   Segment
+		pts []Pt
+	}{
+		{
+			//0
+			unit, SegmentPt(PtXy(-2, 0), PtXy(2, 0)),
+			[]Pt{PtXy(-1, 0), PtXy(1, 0)},
+		}, {
+			unit, SegmentPt(PtXy(0, 0), PtXy(2, 0)),
+			[]Pt{PtXy(1, 0)},
+		}, {
+			unit, SegmentPt(PtXy(-0.5, 0), PtXy(0.5, 0)),
+			nil,
+		}, {
+			unit, SegmentPt(PtXy(1, 0), PtXy(3, 0)),
+			[]Pt{PtXy(1, 0)},
+		}, {
+			unit, SegmentPt(PtXy(-1, 1), PtXy(1, 1)),
+			[]Pt{PtXy(0, 1)},
+		}, {
+			//5
+			unit, SegmentPt(PtXy(0, 1), PtXy(0, 1)),
+			[]Pt{PtXy(0, 1)},
+		},
+	}
+	for h, test := range circleSegmentTests {
+		checkPts("IntersectionCircleSegment", h, test.a, test.b,
+			IntersectionCircleSegment(test.a, test.b), test.pts)
+	}
+
+	circleRayTests := []struct {
+		a   Circle
+		b   Ray
+		pts []Pt
+	}{
+		{
+			//0
+			unit, RayFromVector(PtXy(-2, 0), VectorIj(1, 0)),
+			[]Pt{PtXy(-1, 0), PtXy(1, 0)},
+		}, {
+			unit, RayFromVector(PtXy(0, 0), VectorIj(0, 1)),
+			[]Pt{PtXy(0, 1)},
+		}, {
+			unit, RayFromVector(PtXy(2, 0), VectorIj(1, 0)),
+			nil,
+		}, {
+			unit, RayFromVector(PtXy(-3, 1), VectorIj(1, 0)),
+			[]Pt{PtXy(0, 1)},
+		},
+	}
+	for h, test := range circleRayTests {
+		checkPts("IntersectionCircleRay", h, test.a, test.b,
+			IntersectionCircleRay(test.a, test.b), test.pts)
+	}
+
+	circleCircleTests := []struct {
+		a, b Circle
+		pts  []Pt
+	}{
+		{
+			//0
+			unit, CirclePt(PtXy(1, 0), 1),
+			[]Pt{PtXy(0.5, -0.866025403784), PtXy(0.5, 0.866025403784)},
+		}, {
+			unit, CirclePt(PtXy(2, 0), 1),
+			[]Pt{PtXy(1, 0)},
+		}, {
+			unit, CirclePt(PtXy(0.5, 0), 0.5),
+			[]Pt{PtXy(1, 0)},
+		}, {
+			CirclePt(PtXy(0.5, 0), 0.5), unit,
+			[]Pt{PtXy(1, 0)},
+		}, {
+			unit, CirclePt(PtXy(3, 0), 1),
+			nil,
+		}, {
+			//5
+			unit, CirclePt(PtXy(0.1, 0), 0.2),
+			nil,
+		}, {
+			unit, unit,
+			nil,
+		}, {
+			shifted, CirclePt(PtXy(0, 0), 3),
+			[]Pt{PtXy(-1.749454083, 2.437090563), PtXy(2.829454083, -0.997090563)},
+		},
+	}
+	for h, test := range circleCircleTests {
+		checkPts("IntersectionCircleCircle", h, test.a, test.b,
+			IntersectionCircleCircle(test.a, test.b), test.pts)
+	}
+
+	circleBezierTests := []struct {
+		a   Circle
+		b   Bezier
+		pts []Pt
+	}{
+		{
+			//0
+			unit, BezierPt(PtXy(-2, 0), PtXy(-1, 0), PtXy(1, 0), PtXy(2, 0)),
+			[]Pt{PtXy(-1, 0), PtXy(1, 0)},
+		}, {
+			unit, BezierPt(PtXy(-2, 1), PtXy(-1, 1), PtXy(1, 1), PtXy(2, 1)),
+			[]Pt{PtXy(0, 1)},
+		}, {
+			unit, BezierPt(PtXy(-2, 0), PtXy(-1, 4), PtXy(1, 4), PtXy(2, 0)),
+			nil,
+		}, {
+			unit, BezierPt(PtXy(-2, -2), PtXy(-1, 4), PtXy(1, -4), PtXy(2, 2)),
+			[]Pt{PtXy(-0.928920238, 0.370279883), PtXy(0.928920238, -0.370279883)},
+		}, {
+			CirclePt(PtXy(100, 100), 1), BezierPt(PtXy(-2, 0), PtXy(-1, 4), PtXy(1, 4), PtXy(2, 0)),
+			nil,
+		},
+	}
+	for h, test := range circleBezierTests {
+		pts := IntersectionCircleBezier(test.a, test.b)
+		for _, p := range pts {
+			if c := test.a.ContainsPt(p); c != CONTAINMENT_BOUNDARY {
+				t.Errorf("[%d]IntersectionCircleBezier(%v, %v) (boundary) failed. %v != %v",
+					h, test.a, test.b, c, CONTAINMENT_BOUNDARY)
+			}
+		}
+		checkPts("IntersectionCircleBezier", h, test.a, test.b, pts, test.pts)
+	}
+
+	square := PolygonPt(PtXy(-1, -1), PtXy(1, -1), PtXy(1, 1), PtXy(-1, 1))
+	circlePolygonTests := []struct {
+		a   Circle
+		b   Polygon
+		pts []Pt
+	}{
+		{
+			//0
+			unit, square,
+			[]Pt{PtXy(-1, 0), PtXy(0, -1), PtXy(0, 1), PtXy(1, 0)},
+		}, {
+			CirclePt(PtXy(0, 0), 1.4142135623731), square,
+			[]Pt{PtXy(-1, -1), PtXy(-1, 1), PtXy(1, -1), PtXy(1, 1)},
+		}, {
+			CirclePt(PtXy(0, 0), 0.5), square,
+			nil,
+		},
+	}
+	for h, test := range circlePolygonTests {
+		checkPts("IntersectionCirclePolygon", h, test.a, test.b,
+			IntersectionCirclePolygon(test.a, test.b), test.pts)
+	}
+}
+
+func TestIntersectionQuadraticBezier(t *testing.T) {
+	arch := QuadraticBezierPt(PtXy(0, 0), PtXy(1, 2), PtXy(2, 0))
+	flat := QuadraticBezierPt(PtXy(0, 0.5), PtXy(1, 0.5), PtXy(2, 0.5))
+	checkPts := func(name string, h int, a, b interface{}, pts, expected []Pt) {
+		if len(pts) != len(expected) {
+			t.Errorf("[%d]%s(%v, %v) (length) failed. %v != %v",
+				h, name, a, b, pts, expected)
+			return
+		}
+		for i := 0; i < len(pts); i++ {
+			if !IsEqualPair(pts[i], expected[i]) {
+				t.Errorf("[%d][%d]%s(%v, %v) failed. %v != %v",
+					h, i, name, a, b, pts[i], expected[i])
+			}
+		}
+	}
+
+	lineTests := []struct {
+		a   Line
+		b   QuadraticBezier
+		pts []Pt
+	}{
+		{
+			//0
+			LineFromPt(PtXy(0, 0.5), PtXy(1, 0.5)), arch,
+			[]Pt{PtXy(0.292893218813, 0.5), PtXy(1.707106781187, 0.5)},
+		}, {
+			LineFromPt(PtXy(0, 1), PtXy(1, 1)), arch,
+			[]Pt{PtXy(1, 1)},
+		}, {
+			LineFromPt(PtXy(0, 2), PtXy(1, 2)), arch,
+			nil,
+		}, {
+			LineYAxis, arch,
+			[]Pt{PtXy(0, 0)},
+		},
+	}
+	for h, test := range lineTests {
+		checkPts("IntersectionLineQuadraticBezier", h, test.a, test.b,
+			IntersectionLineQuadraticBezier(test.a, test.b), test.pts)
+	}
+
+	segmentTests := []struct {
+		a   Segment
+		b   QuadraticBezier
+		pts []Pt
+	}{
+		{
+			//0
+			SegmentPt(PtXy(0, 0.5), PtXy(1, 0.5)), arch,
+			[]Pt{PtXy(0.292893218813, 0.5)},
+		}, {
+			SegmentPt(PtXy(0.5, 0.5), PtXy(1.5, 0.5)), arch,
+			nil,
+		},
+	}
+	for h, test := range segmentTests {
+		checkPts("IntersectionSegmentQuadraticBezier", h, test.a, test.b,
+			IntersectionSegmentQuadraticBezier(test.a, test.b), test.pts)
+	}
+
+	circleTests := []struct {
+		a   Circle
+		b   QuadraticBezier
+		pts []Pt
+	}{
+		{
+			//0
+			CirclePt(PtXy(1, 0), 1), arch,
+			[]Pt{PtXy(0, 0), PtXy(1, 1), PtXy(2, 0)},
+		}, {
+			CirclePt(PtXy(1, 0), 0.5), arch,
+			nil,
+		},
+	}
+	for h, test := range circleTests {
+		checkPts("IntersectionCircleQuadraticBezier", h, test.a, test.b,
+			IntersectionCircleQuadraticBezier(test.a, test.b), test.pts)
+	}
+
+	polygonTests := []struct {
+		a   Polygon
+		b   QuadraticBezier
+		pts []Pt
+	}{
+		{
+			//0
+			PolygonPt(PtXy(0, 0.5), PtXy(2, 0.5), PtXy(2, 2), PtXy(0, 2)), arch,
+			[]Pt{PtXy(0.292893218813, 0.5), PtXy(1.707106781187, 0.5)},
+		},
+	}
+	for h, test := range polygonTests {
+		checkPts("IntersectionPolygonQuadraticBezier", h, test.a, test.b,
+			IntersectionPolygonQuadraticBezier(test.a, test.b), test.pts)
+	}
+
+	quadraticTests := []struct {
+		a, b QuadraticBezier
+		pts  []Pt
+	}{
+		{
+			//0
+			arch, QuadraticBezierPt(PtXy(0, 1), PtXy(1, -1), PtXy(2, 1)),
+			[]Pt{PtXy(0.292893218813, 0.5), PtXy(1.707106781187, 0.5)},
+		}, {
+			arch, QuadraticBezierPt(PtXy(0, 0), PtXy(1, -2), PtXy(2, 0)),
+			[]Pt{PtXy(0, 0), PtXy(2, 0)},
+		}, {
+			arch, QuadraticBezierPt(PtXy(-1, -1), PtXy(1, -1), PtXy(2, 1)),
+			[]Pt{PtXy(1.710731678, 0.494860482)},
+		}, {
+			// The same parabola as arch, but longer. Only points on arch
+			// are kept.
+			QuadraticBezierPt(PtXy(-5, -35), PtXy(1, 37), PtXy(7, -35)), QuadraticBezierPt(PtXy(-1, -1), PtXy(1, -1), PtXy(2, 1)),
+			[]Pt{PtXy(-0.396677049, -0.950706779), PtXy(1.710731678, 0.494860482)},
+		}, {
+			flat, arch,
+			[]Pt{PtXy(0.292893218813, 0.5), PtXy(1.707106781187, 0.5)},
+		},
+	}
+	for h, test := range quadraticTests {
+		checkPts("IntersectionQuadraticBezierQuadraticBezier", h, test.a, test.b,
+			IntersectionQuadraticBezierQuadraticBezier(test.a, test.b), test.pts)
+	}
+
+	cubicTests := []struct {
+		a   QuadraticBezier
+		b   Bezier
+		pts []Pt
+	}{
+		{
+			//0
+			arch, BezierPt(PtXy(0, 1), PtXy(1, -1), PtXy(1, 2), PtXy(2, 0.2)),
+			[]Pt{PtXy(0.297696071, 0.506769191), PtXy(1.44865708, 0.798706825)},
+		}, {
+			flat, arch.Elevate(),
+			[]Pt{PtXy(0.292893218813, 0.5), PtXy(1.707106781187, 0.5)},
+		}, {
+			arch, BezierPt(PtXy(0, 3), PtXy(1, 3), PtXy(2, 3), PtXy(3, 3)),
+			nil,
+		},
+	}
+	for h, test := range cubicTests {
+		checkPts("IntersectionQuadraticBezierBezier", h, test.a, test.b,
+			IntersectionQuadraticBezierBezier(test.a, test.b), test.pts)
+	}
+}
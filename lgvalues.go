@@ -135,3 +135,16 @@ var (
 		0.0017832807216964329472960791449719331799593472719279556695308063655858546954239803486698215802150348282744786016134857283616955449868451969230490863774274598030023211055562492709717566919237924255297982774711177411074145151155610163293142044147991553384925940046957893721166251082473659733,
 	}
 )
+
+// legendreGaussIntegrate approximates the integral of \c f between \c lo and
+// \c hi using the Legendre-Gauss tables.
+func legendreGaussIntegrate(f func(float64) float64, lo, hi float64) float64 {
+	// see https://pomax.github.io/bezierinfo/legendre-gauss.html
+	halfz := (hi - lo) / 2
+	mid := lo + halfz
+	var sum float64
+	for h := 0; h < len(legendregauss_weight); h++ {
+		sum += legendregauss_weight[h] * f(mid+halfz*legendregauss_abscissa[h])
+	}
+	return sum * halfz
+}
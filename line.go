@@ -201,6 +201,23 @@ func (le Line) Vector() Vector {
 	return VectorFromVec2(ij)
 }
 
+// ClosestPt returns the value of \c t, the point, and the distance of the
+// point of the line closest to \c p. The value of \c t is the distance along
+// \c Vector from the point of the line closest to the origin.
+func (le Line) ClosestPt(p Pt) (float64, Pt, Length) {
+	n := le.NormalizeUnit()
+	a, b, c := n.Abc()
+	// Signed distance from the line, along the unit normal (a, b).
+	d := a*p.X() + b*p.Y() + c
+	foot := p.Add(VectorIj(a, b).Scale(-d))
+	// The point closest to the origin is -c along the normal.
+	t := PtXy(-a*c, -b*c).VectorTo(foot).Dot(n.Vector())
+	if d < 0 {
+		d = -d
+	}
+	return float64(t), foot, d
+}
+
 // XForY returns the X value for a given Y. Returns \c NaN if \c IsHorizontal()
 // or \c IsUnknown() are true.
 func (le Line) XForY(y Length) Length {
@@ -270,6 +287,16 @@ func RayFromVector(begin Pt, direction Vector) Ray {
 
 func (r Ray) Angle() Radians { return r.v.Angle() }
 func (r Ray) Begin() Pt      { return r.b }
+
+// ClosestPt returns the value of \c t, the point, and the distance of the
+// point of the ray closest to \c p. The value of \c t is the distance from
+// \c Begin.
+func (r Ray) ClosestPt(p Pt) (float64, Pt, Length) {
+	t := Maximum(0, r.b.VectorTo(p).Dot(r.v))
+	c := r.b.Add(r.v.Scale(t))
+	return float64(t), c, c.VectorTo(p).Magnitude()
+}
+
 func (r Ray) OrErr() (Ray, *FloatingPointError) {
 	if _, err := r.b.OrErr(); err != nil {
 		return r, err
@@ -322,6 +349,10 @@ func (s Segment) End() Pt                { return s.e }
 func (s Segment) Length() Length         { return s.b.VectorTo(s.e).Magnitude() }
 func (s Segment) Angle() Radians         { return s.b.VectorTo(s.e).Angle() }
 func (s Segment) Points() []Pt           { return []Pt{s.b, s.e} }
+
+// HullPts implements the HullPtser interface. The points are the ends.
+func (s Segment) HullPts(tolerance Length) []Pt { return s.Points() }
+
 func (s Segment) OrErr() (Segment, *FloatingPointError) {
 	if _, err := s.b.OrErr(); err != nil {
 		return s, err
@@ -335,6 +366,31 @@ func (s Segment) String() string {
 }
 func (s Segment) Reverse() Segment { return SegmentPt(s.e, s.b) }
 
+// ClosestPt returns the value of \c t, the point, and the distance of the
+// point of the segment closest to \c p. The value of \c t is 0 at \c Begin
+// and 1 at \c End.
+func (s Segment) ClosestPt(p Pt) (float64, Pt, Length) {
+	v := s.b.VectorTo(s.e)
+	vv := v.Dot(v)
+	if IsZero(vv) {
+		return 0, s.b, s.b.VectorTo(p).Magnitude()
+	}
+	t := Clamp(0, s.b.VectorTo(p).Dot(v)/vv, 1)
+	c := s.b.Add(v.Scale(t))
+	return float64(t), c, c.VectorTo(p).Magnitude()
+}
+
+// isPtOnSegment tests if \c p is within tolerance of any point on \c s.
+func isPtOnSegment(p Pt, s Segment) bool {
+	v := s.b.VectorTo(s.e)
+	d := v.Dot(v)
+	if IsZero(d) {
+		return IsEqualPair(p, s.b)
+	}
+	t := Clamp(0, s.b.VectorTo(p).Dot(v)/d, 1)
+	return IsEqualPair(p, s.b.Add(v.Scale(t)))
+}
+
 func IsEqualPts[T OrderedPtser](a, b T) bool {
 	as, bs := a.Points(), b.Points()
 	if len(as) != len(bs) {
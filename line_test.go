@@ -309,3 +309,84 @@ func TestSegment(t *testing.T) {
 		}
 	}
 }
+
+func TestClosestPtLine(t *testing.T) {
+	lineTests := []struct {
+		a    Line
+		p    Pt
+		t    float64
+		pt   Pt
+		dist Length
+	}{
+		{
+			//0
+			LineXAxis, PtXy(3, -2),
+			-3, PtXy(3, 0), 2,
+		}, {
+			LineFromPt(PtXy(0, 1), PtXy(1, 2)), PtXy(2, 0),
+			1.414213562373, PtXy(0.5, 1.5), 2.121320343560,
+		}, {
+			LineYAxis, PtXy(0, 5),
+			5, PtXy(0, 5), 0,
+		},
+	}
+	for h, test := range lineTests {
+		tv, pt, dist := test.a.ClosestPt(test.p)
+		if !IsEqual(tv, test.t) || !IsEqualPair(pt, test.pt) || !IsEqual(dist, test.dist) {
+			t.Errorf("[%d](%v).ClosestPt(%v) failed. %f, %v, %f != %f, %v, %f",
+				h, test.a, test.p, tv, pt, dist, test.t, test.pt, test.dist)
+		}
+	}
+
+	ray := RayFromVector(PtXy(1, 1), VectorIj(2, 0))
+	rayTests := []struct {
+		a    Ray
+		p    Pt
+		t    float64
+		pt   Pt
+		dist Length
+	}{
+		{
+			//0
+			ray, PtXy(0, 3),
+			0, PtXy(1, 1), 2.236067977500,
+		}, {
+			ray, PtXy(4, 3),
+			3, PtXy(4, 1), 2,
+		},
+	}
+	for h, test := range rayTests {
+		tv, pt, dist := test.a.ClosestPt(test.p)
+		if !IsEqual(tv, test.t) || !IsEqualPair(pt, test.pt) || !IsEqual(dist, test.dist) {
+			t.Errorf("[%d](%v).ClosestPt(%v) failed. %f, %v, %f != %f, %v, %f",
+				h, test.a, test.p, tv, pt, dist, test.t, test.pt, test.dist)
+		}
+	}
+
+	segmentTests := []struct {
+		a    Segment
+		p    Pt
+		t    float64
+		pt   Pt
+		dist Length
+	}{
+		{
+			//0
+			SegmentPt(PtXy(0, 0), PtXy(4, 0)), PtXy(1, 2),
+			0.25, PtXy(1, 0), 2,
+		}, {
+			SegmentPt(PtXy(0, 0), PtXy(4, 0)), PtXy(7, 4),
+			1, PtXy(4, 0), 5,
+		}, {
+			SegmentPt(PtXy(2, 2), PtXy(2, 2)), PtXy(5, 6),
+			0, PtXy(2, 2), 5,
+		},
+	}
+	for h, test := range segmentTests {
+		tv, pt, dist := test.a.ClosestPt(test.p)
+		if !IsEqual(tv, test.t) || !IsEqualPair(pt, test.pt) || !IsEqual(dist, test.dist) {
+			t.Errorf("[%d](%v).ClosestPt(%v) failed. %f, %v, %f != %f, %v, %f",
+				h, test.a, test.p, tv, pt, dist, test.t, test.pt, test.dist)
+		}
+	}
+}
@@ -0,0 +1,461 @@
+package figuring
+
+import (
+	"math"
+	"math/big"
+	"sort"
+)
+
+const (
+	// orientErrBound and incircleErrBound bound the floating point error of
+	// the fast predicates.
+	// see https://www.cs.cmu.edu/~quake/robust.html
+	orientErrBound   = 3.3306690738754716e-16
+	incircleErrBound = 1.1102230246251577e-15
+)
+
+// orientPts returns a positive value if \c a, \c b, and \c c are in
+// counter-clockwise order, a negative value if they are clockwise, and zero
+// if they are collinear. The sign is exact; nearly collinear points fall back
+// to exact arithmetic.
+func orientPts(a, b, c Pt) float64 {
+	acx, acy := a.xy[0]-c.xy[0], a.xy[1]-c.xy[1]
+	bcx, bcy := b.xy[0]-c.xy[0], b.xy[1]-c.xy[1]
+	left, right := acx*bcy, acy*bcx
+	det := left - right
+	bound := orientErrBound * (math.Abs(left) + math.Abs(right))
+	if det > bound || -det > bound || math.IsNaN(det) || math.IsInf(det, 0) {
+		return det
+	}
+
+	r := func(f float64) *big.Rat { return new(big.Rat).SetFloat64(f) }
+	sub := func(x, y float64) *big.Rat { return new(big.Rat).Sub(r(x), r(y)) }
+	eacx, eacy := sub(a.xy[0], c.xy[0]), sub(a.xy[1], c.xy[1])
+	ebcx, ebcy := sub(b.xy[0], c.xy[0]), sub(b.xy[1], c.xy[1])
+	exact := new(big.Rat).Sub(
+		new(big.Rat).Mul(eacx, ebcy),
+		new(big.Rat).Mul(eacy, ebcx),
+	)
+	return float64(exact.Sign())
+}
+
+// incirclePts returns a positive value if \c d is inside the circle through
+// \c a, \c b, and \c c, a negative value if it is outside, and zero if it is
+// on the circle. \c a, \c b, and \c c must be counter-clockwise. The sign is
+// exact; nearly cocircular points fall back to exact arithmetic.
+func incirclePts(a, b, c, d Pt) float64 {
+	adx, ady := a.xy[0]-d.xy[0], a.xy[1]-d.xy[1]
+	bdx, bdy := b.xy[0]-d.xy[0], b.xy[1]-d.xy[1]
+	cdx, cdy := c.xy[0]-d.xy[0], c.xy[1]-d.xy[1]
+
+	bdxcdy, cdxbdy := bdx*cdy, cdx*bdy
+	cdxady, adxcdy := cdx*ady, adx*cdy
+	adxbdy, bdxady := adx*bdy, bdx*ady
+	alift := adx*adx + ady*ady
+	blift := bdx*bdx + bdy*bdy
+	clift := cdx*cdx + cdy*cdy
+
+	det := alift*(bdxcdy-cdxbdy) + blift*(cdxady-adxcdy) + clift*(adxbdy-bdxady)
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*alift +
+		(math.Abs(cdxady)+math.Abs(adxcdy))*blift +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*clift
+	bound := incircleErrBound * permanent
+	if det > bound || -det > bound || math.IsNaN(det) || math.IsInf(det, 0) {
+		return det
+	}
+
+	r := func(f float64) *big.Rat { return new(big.Rat).SetFloat64(f) }
+	sub := func(x, y float64) *big.Rat { return new(big.Rat).Sub(r(x), r(y)) }
+	mul := func(x, y *big.Rat) *big.Rat { return new(big.Rat).Mul(x, y) }
+	add := func(x, y *big.Rat) *big.Rat { return new(big.Rat).Add(x, y) }
+	eadx, eady := sub(a.xy[0], d.xy[0]), sub(a.xy[1], d.xy[1])
+	ebdx, ebdy := sub(b.xy[0], d.xy[0]), sub(b.xy[1], d.xy[1])
+	ecdx, ecdy := sub(c.xy[0], d.xy[0]), sub(c.xy[1], d.xy[1])
+	ealift := add(mul(eadx, eadx), mul(eady, eady))
+	eblift := add(mul(ebdx, ebdx), mul(ebdy, ebdy))
+	eclift := add(mul(ecdx, ecdx), mul(ecdy, ecdy))
+	exact := add(
+		add(
+			mul(ealift, new(big.Rat).Sub(mul(ebdx, ecdy), mul(ecdx, ebdy))),
+			mul(eblift, new(big.Rat).Sub(mul(ecdx, eady), mul(eadx, ecdy))),
+		),
+		mul(eclift, new(big.Rat).Sub(mul(eadx, ebdy), mul(ebdx, eady))),
+	)
+	return float64(exact.Sign())
+}
+
+// mesh is a triangulation of a set of points. Triangles are stored as
+// counter-clockwise indexes into \c pts. Each directed side of a triangle maps
+// to that triangle, so the neighbor across a side is found by looking up the
+// reverse of the side.
+type mesh struct {
+	pts   []Pt
+	verts []int
+	tris  [][3]int
+	free  []int
+	sides map[[2]int]int
+	fixed map[[2]int]bool
+	last  int
+}
+
+// delaunayMesh creates the Delaunay triangulation of \c pts. The returned
+// slice maps each point to the vertex used for it in the mesh. Duplicate
+// points map to the first copy, and points in error map to -1.
+func delaunayMesh(pts []Pt) (*mesh, []int) {
+	// see https://en.wikipedia.org/wiki/Bowyer%E2%80%93Watson_algorithm
+	n := len(pts)
+	m := &mesh{
+		pts:   make([]Pt, n, n+3),
+		sides: make(map[[2]int]int),
+		fixed: make(map[[2]int]bool),
+	}
+	copy(m.pts, pts)
+	vertex := make([]int, n)
+	valid := make([]Pt, 0, n)
+	for h, p := range pts {
+		vertex[h] = -1
+		if _, err := p.OrErr(); err == nil {
+			valid = append(valid, p)
+		}
+	}
+	if len(valid) == 0 {
+		return m, vertex
+	}
+
+	// Start with a triangle much larger than the points.
+	lx, mx, ly, my := LimitsPts(valid)
+	size := Maximum(mx-lx, my-ly, 1)
+	center := PtXy((lx+mx)/2, (ly+my)/2)
+	s := size * 1000
+	m.pts = append(m.pts,
+		center.Add(VectorIj(-2*s, -s)),
+		center.Add(VectorIj(2*s, -s)),
+		center.Add(VectorIj(0, 2*s)),
+	)
+	m.addTri(n, n+1, n+2)
+
+	for h, p := range pts {
+		if _, err := p.OrErr(); err == nil {
+			vertex[h] = m.insert(h)
+		}
+	}
+
+	// Remove the starting triangle. The points near the hull may not have
+	// been connected, so fill the hull back in.
+	for t, tri := range m.tris {
+		if tri[0] >= n || tri[1] >= n || tri[2] >= n {
+			m.removeTri(t)
+		}
+	}
+	m.pts = m.pts[:n]
+	m.fillHull()
+	m.legalize(m.sortedSides())
+	return m, vertex
+}
+
+// addTri adds a counter-clockwise triangle, reusing removed slots.
+func (m *mesh) addTri(a, b, c int) int {
+	var t int
+	if len(m.free) > 0 {
+		t = m.free[len(m.free)-1]
+		m.free = m.free[:len(m.free)-1]
+		m.tris[t] = [3]int{a, b, c}
+	} else {
+		t = len(m.tris)
+		m.tris = append(m.tris, [3]int{a, b, c})
+	}
+	m.sides[[2]int{a, b}] = t
+	m.sides[[2]int{b, c}] = t
+	m.sides[[2]int{c, a}] = t
+	m.last = t
+	return t
+}
+
+// removeTri removes a triangle, leaving its slot for reuse.
+func (m *mesh) removeTri(t int) {
+	tri := m.tris[t]
+	if tri[0] < 0 {
+		return
+	}
+	for h := 0; h < 3; h++ {
+		delete(m.sides, [2]int{tri[h], tri[(h+1)%3]})
+	}
+	m.tris[t] = [3]int{-1, -1, -1}
+	m.free = append(m.free, t)
+}
+
+// third returns the vertex of triangle \c t that is not \c a or \c b.
+func (m *mesh) third(t, a, b int) int {
+	for _, v := range m.tris[t] {
+		if v != a && v != b {
+			return v
+		}
+	}
+	return -1
+}
+
+// triangles returns the triangles that have not been removed.
+func (m *mesh) triangles() [][3]int {
+	tris := make([][3]int, 0, len(m.tris)-len(m.free))
+	for _, tri := range m.tris {
+		if tri[0] >= 0 {
+			tris = append(tris, tri)
+		}
+	}
+	return tris
+}
+
+// sortedSides returns every directed side in a repeatable order.
+func (m *mesh) sortedSides() [][2]int {
+	sides := make([][2]int, 0, len(m.sides))
+	for side := range m.sides {
+		sides = append(sides, side)
+	}
+	sort.Slice(sides, func(i, j int) bool {
+		if sides[i][0] == sides[j][0] {
+			return sides[i][1] < sides[j][1]
+		}
+		return sides[i][0] < sides[j][0]
+	})
+	return sides
+}
+
+// locate returns the triangle that contains \c p.
+func (m *mesh) locate(p Pt) int {
+	t := m.last
+	for steps := 0; steps < len(m.tris); steps++ {
+		tri := m.tris[t]
+		moved := false
+		for h := 0; h < 3 && !moved; h++ {
+			a, b := tri[h], tri[(h+1)%3]
+			if orientPts(m.pts[a], m.pts[b], p) < 0 {
+				if next, ok := m.sides[[2]int{b, a}]; ok {
+					t, moved = next, true
+				}
+			}
+		}
+		if !moved {
+			return t
+		}
+	}
+
+	// The walk should always finish, but fall back to checking every
+	// triangle.
+	for t, tri := range m.tris {
+		if tri[0] >= 0 &&
+			orientPts(m.pts[tri[0]], m.pts[tri[1]], p) >= 0 &&
+			orientPts(m.pts[tri[1]], m.pts[tri[2]], p) >= 0 &&
+			orientPts(m.pts[tri[2]], m.pts[tri[0]], p) >= 0 {
+			return t
+		}
+	}
+	return m.last
+}
+
+// insert adds the point at index \c v to the triangulation, and returns the
+// vertex used for the point.
+func (m *mesh) insert(v int) int {
+	p := m.pts[v]
+	t := m.locate(p)
+	for _, w := range m.tris[t] {
+		if IsEqualPair(m.pts[w], p) {
+			return w
+		}
+	}
+
+	// Find the triangles whose circumcircle contains the point.
+	bad := []int{t}
+	isBad := map[int]bool{t: true}
+	for h := 0; h < len(bad); h++ {
+		tri := m.tris[bad[h]]
+		for i := 0; i < 3; i++ {
+			a, b := tri[i], tri[(i+1)%3]
+			n, ok := m.sides[[2]int{b, a}]
+			if !ok || isBad[n] {
+				continue
+			}
+			nt := m.tris[n]
+			if incirclePts(m.pts[nt[0]], m.pts[nt[1]], m.pts[nt[2]], p) > 0 {
+				isBad[n] = true
+				bad = append(bad, n)
+			}
+		}
+	}
+
+	// Replace them with triangles connecting the point to the edge of the
+	// hole they leave behind.
+	var boundary [][2]int
+	for _, t := range bad {
+		tri := m.tris[t]
+		for i := 0; i < 3; i++ {
+			a, b := tri[i], tri[(i+1)%3]
+			if n, ok := m.sides[[2]int{b, a}]; !ok || !isBad[n] {
+				boundary = append(boundary, [2]int{a, b})
+			}
+		}
+	}
+	for _, t := range bad {
+		m.removeTri(t)
+	}
+	for _, side := range boundary {
+		m.addTri(side[0], side[1], v)
+	}
+	m.verts = append(m.verts, v)
+	return v
+}
+
+// fillHull adds triangles to the outside of the mesh until the outside is
+// convex.
+func (m *mesh) fillHull() {
+	isOutside := func(a, b int) bool {
+		_, ok := m.sides[[2]int{a, b}]
+		_, twin := m.sides[[2]int{b, a}]
+		return ok && !twin
+	}
+	for changed := true; changed; {
+		changed = false
+		next := make(map[int]int)
+		var starts []int
+		for _, side := range m.sortedSides() {
+			if isOutside(side[0], side[1]) {
+				next[side[0]] = side[1]
+				starts = append(starts, side[0])
+			}
+		}
+		for _, a := range starts {
+			b := next[a]
+			c, ok := next[b]
+			if !ok || !isOutside(a, b) || !isOutside(b, c) {
+				continue
+			}
+			if orientPts(m.pts[a], m.pts[b], m.pts[c]) < 0 {
+				m.addTri(a, c, b)
+				changed = true
+			}
+		}
+	}
+}
+
+// flip replaces the side between \c a and \c b with the side between the
+// other two points of the triangles that share it. Returns false if the two
+// triangles do not form a convex quadrilateral.
+func (m *mesh) flip(a, b int) bool {
+	t1, ok1 := m.sides[[2]int{a, b}]
+	t2, ok2 := m.sides[[2]int{b, a}]
+	if !ok1 || !ok2 {
+		return false
+	}
+	c, d := m.third(t1, a, b), m.third(t2, b, a)
+	pa, pb, pc, pd := m.pts[a], m.pts[b], m.pts[c], m.pts[d]
+	if orientPts(pa, pd, pc) <= 0 || orientPts(pd, pb, pc) <= 0 {
+		return false
+	}
+	m.removeTri(t1)
+	m.removeTri(t2)
+	m.addTri(a, d, c)
+	m.addTri(d, b, c)
+	return true
+}
+
+// legalize flips sides until the triangles around them are Delaunay. Fixed
+// sides are never flipped.
+func (m *mesh) legalize(stack [][2]int) {
+	for len(stack) > 0 {
+		side := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		a, b := side[0], side[1]
+		if m.fixed[meshKey(a, b)] {
+			continue
+		}
+		t1, ok1 := m.sides[[2]int{a, b}]
+		t2, ok2 := m.sides[[2]int{b, a}]
+		if !ok1 || !ok2 {
+			continue
+		}
+		c, d := m.third(t1, a, b), m.third(t2, b, a)
+		if incirclePts(m.pts[a], m.pts[b], m.pts[c], m.pts[d]) <= 0 {
+			continue
+		}
+		if m.flip(a, b) {
+			stack = append(stack, [2]int{a, d}, [2]int{d, b}, [2]int{b, c}, [2]int{c, a})
+		}
+	}
+}
+
+// constrain forces a side between \c u and \c v into the triangulation, and
+// marks it so it will not be flipped. Returns false if the side could not be
+// added because it crosses another fixed side.
+func (m *mesh) constrain(u, v int) bool {
+	// see https://doi.org/10.1016/0045-7949(93)90239-A (Sloan)
+	if u == v || u < 0 || v < 0 {
+		return true
+	}
+	pu, pv := m.pts[u], m.pts[v]
+
+	// Points exactly on the side split it in two.
+	for _, w := range m.verts {
+		if w == u || w == v {
+			continue
+		}
+		pw := m.pts[w]
+		if orientPts(pu, pv, pw) == 0 &&
+			pu.VectorTo(pw).Dot(pu.VectorTo(pv)) > 0 &&
+			pv.VectorTo(pw).Dot(pv.VectorTo(pu)) > 0 {
+			return m.constrain(u, w) && m.constrain(w, v)
+		}
+	}
+
+	crosses := func(a, b int) bool {
+		if a == u || a == v || b == u || b == v {
+			return false
+		}
+		pa, pb := m.pts[a], m.pts[b]
+		o1, o2 := orientPts(pu, pv, pa), orientPts(pu, pv, pb)
+		o3, o4 := orientPts(pa, pb, pu), orientPts(pa, pb, pv)
+		return ((o1 > 0 && o2 < 0) || (o1 < 0 && o2 > 0)) &&
+			((o3 > 0 && o4 < 0) || (o3 < 0 && o4 > 0))
+	}
+
+	var crossing [][2]int
+	for _, side := range m.sortedSides() {
+		if side[0] < side[1] && crosses(side[0], side[1]) {
+			if m.fixed[meshKey(side[0], side[1])] {
+				return false
+			}
+			crossing = append(crossing, side)
+		}
+	}
+
+	var created [][2]int
+	for attempts := 0; len(crossing) > 0; attempts++ {
+		if attempts > 100*len(m.sides) {
+			return false
+		}
+		side := crossing[0]
+		crossing = crossing[1:]
+		a, b := side[0], side[1]
+		t1 := m.sides[[2]int{a, b}]
+		t2 := m.sides[[2]int{b, a}]
+		c, d := m.third(t1, a, b), m.third(t2, b, a)
+		if !m.flip(a, b) {
+			crossing = append(crossing, side)
+			continue
+		}
+		if crosses(c, d) {
+			crossing = append(crossing, [2]int{c, d})
+		} else {
+			created = append(created, [2]int{c, d})
+		}
+	}
+	m.fixed[meshKey(u, v)] = true
+	m.legalize(created)
+	return true
+}
+
+// meshKey identifies a side, ignoring direction.
+func meshKey(a, b int) [2]int {
+	if b < a {
+		return [2]int{b, a}
+	}
+	return [2]int{a, b}
+}
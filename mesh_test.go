@@ -0,0 +1,145 @@
+package figuring
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOrientPts(t *testing.T) {
+	up, down := math.Nextafter(0.5, 1), math.Nextafter(0.5, 0)
+	orientTests := []struct {
+		a, b, c Pt
+		sign    int
+	}{
+		{PtXy(0, 0), PtXy(1, 0), PtXy(0, 1), 1},
+		{PtXy(0, 0), PtXy(0, 1), PtXy(1, 0), -1},
+		{PtXy(12, 12), PtXy(24, 24), PtXy(0.5, 0.5), 0},
+		// One unit in the last place to either side of the line.
+		{PtXy(12, 12), PtXy(24, 24), PtXy(Length(up), 0.5), -1},
+		{PtXy(12, 12), PtXy(24, 24), PtXy(Length(down), 0.5), 1},
+		{PtXy(12, 12), PtXy(24, 24), PtXy(0.5, Length(up)), 1},
+		// Collinear far from the origin.
+		{PtXy(1e15, 1e15), PtXy(1e15+2, 1e15+2), PtXy(1e15+4, 1e15+4), 0},
+		{PtXy(1e15, 1e15), PtXy(1e15+2, 1e15+2), PtXy(1e15+4, 1e15+4.125), 1},
+	}
+	for h, test := range orientTests {
+		if sign := signOf(orientPts(test.a, test.b, test.c)); sign != test.sign {
+			t.Errorf("[%d]orientPts(%v, %v, %v) failed. %d != %d",
+				h, test.a, test.b, test.c, sign, test.sign)
+		}
+		// Rotating the points keeps the sign, and swapping two flips it.
+		if sign := signOf(orientPts(test.b, test.c, test.a)); sign != test.sign {
+			t.Errorf("[%d]orientPts(%v, %v, %v) (rotated) failed. %d != %d",
+				h, test.b, test.c, test.a, sign, test.sign)
+		}
+		if sign := signOf(orientPts(test.b, test.a, test.c)); sign != -test.sign {
+			t.Errorf("[%d]orientPts(%v, %v, %v) (swapped) failed. %d != %d",
+				h, test.b, test.a, test.c, sign, -test.sign)
+		}
+	}
+}
+
+func TestIncirclePts(t *testing.T) {
+	in, out := math.Nextafter(-1, 0), math.Nextafter(-1, -2)
+	incircleTests := []struct {
+		a, b, c, d Pt
+		sign       int
+	}{
+		{PtXy(1, 0), PtXy(0, 1), PtXy(-1, 0), PtXy(0, 0), 1},
+		{PtXy(1, 0), PtXy(0, 1), PtXy(-1, 0), PtXy(2, 2), -1},
+		{PtXy(1, 0), PtXy(0, 1), PtXy(-1, 0), PtXy(0, -1), 0},
+		// One unit in the last place inside and outside the circle.
+		{PtXy(1, 0), PtXy(0, 1), PtXy(-1, 0), PtXy(0, Length(in)), 1},
+		{PtXy(1, 0), PtXy(0, 1), PtXy(-1, 0), PtXy(0, Length(out)), -1},
+		// Cocircular far from the origin.
+		{PtXy(1e6+3, 1e6), PtXy(1e6, 1e6+3), PtXy(1e6-3, 1e6), PtXy(1e6, 1e6-3), 0},
+		{PtXy(1e6+3, 1e6), PtXy(1e6, 1e6+3), PtXy(1e6-3, 1e6), PtXy(1e6, 1e6-2.9999999), 1},
+		{PtXy(1e6+3, 1e6), PtXy(1e6, 1e6+3), PtXy(1e6-3, 1e6), PtXy(1e6, 1e6-3.0000001), -1},
+	}
+	for h, test := range incircleTests {
+		if sign := signOf(incirclePts(test.a, test.b, test.c, test.d)); sign != test.sign {
+			t.Errorf("[%d]incirclePts(%v, %v, %v, %v) failed. %d != %d",
+				h, test.a, test.b, test.c, test.d, sign, test.sign)
+		}
+		if sign := signOf(incirclePts(test.b, test.c, test.a, test.d)); sign != test.sign {
+			t.Errorf("[%d]incirclePts(%v, %v, %v, %v) (rotated) failed. %d != %d",
+				h, test.b, test.c, test.a, test.d, sign, test.sign)
+		}
+	}
+}
+
+func TestMeshConstrain(t *testing.T) {
+	// The side from the first point to the second crosses the triangles
+	// between the points above and below it.
+	pts := []Pt{
+		PtXy(0, 0), PtXy(10, 0),
+		PtXy(2, 1), PtXy(4, 1), PtXy(6, 1), PtXy(8, 1),
+		PtXy(3, -1), PtXy(5, -1), PtXy(7, -1),
+		PtXy(5, 3), PtXy(5, -3),
+	}
+	crosses := func(p, q Pt) bool {
+		return signOf(orientPts(pts[0], pts[1], p))*signOf(orientPts(pts[0], pts[1], q)) < 0 &&
+			signOf(orientPts(p, q, pts[0]))*signOf(orientPts(p, q, pts[1])) < 0
+	}
+	m, vertex := delaunayMesh(pts)
+	u, v := vertex[0], vertex[1]
+	if _, ok := m.sides[[2]int{u, v}]; ok {
+		t.Fatalf("delaunayMesh(%v) failed. side %d-%d should not exist before constrain", pts, u, v)
+	}
+	var crossing int
+	for _, side := range m.sortedSides() {
+		if side[0] < side[1] && crosses(m.pts[side[0]], m.pts[side[1]]) {
+			crossing++
+		}
+	}
+	if crossing < 3 {
+		t.Fatalf("delaunayMesh(%v) failed. %d sides cross %d-%d, expected several", pts, crossing, u, v)
+	}
+	if !m.constrain(u, v) {
+		t.Fatalf("constrain(%d, %d) failed. should succeed", u, v)
+	}
+	_, forward := m.sides[[2]int{u, v}]
+	_, backward := m.sides[[2]int{v, u}]
+	if !forward || !backward || !m.fixed[meshKey(u, v)] {
+		t.Errorf("constrain(%d, %d) failed. side %t, %t, fixed %t",
+			u, v, forward, backward, m.fixed[meshKey(u, v)])
+	}
+
+	// The triangles are still counter-clockwise, cover the hull, and don't
+	// cross the constrained side.
+	var area Length
+	for _, tri := range m.triangles() {
+		a, b, c := m.pts[tri[0]], m.pts[tri[1]], m.pts[tri[2]]
+		if orientPts(a, b, c) <= 0 {
+			t.Errorf("constrain(%d, %d) failed. %v is not counter-clockwise", u, v, tri)
+		}
+		area += PolygonPt(a, b, c).Area()
+		for h := range tri {
+			if p, q := tri[h], tri[(h+1)%3]; crosses(m.pts[p], m.pts[q]) {
+				t.Errorf("constrain(%d, %d) failed. side %d-%d crosses it", u, v, p, q)
+			}
+		}
+	}
+	if hull := ConvexHullPts(pts...).Area(); !IsEqual(area, hull) {
+		t.Errorf("constrain(%d, %d) (area) failed. %f != %f", u, v, area, hull)
+	}
+
+	// A side that crosses the fixed side can't be added.
+	if m.constrain(vertex[9], vertex[10]) {
+		t.Errorf("constrain(%d, %d) failed. should cross the fixed side", vertex[9], vertex[10])
+	}
+	// Adding the same side again is fine.
+	if !m.constrain(v, u) {
+		t.Errorf("constrain(%d, %d) (again) failed. should succeed", v, u)
+	}
+}
+
+func signOf(v float64) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,541 @@
+package figuring
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// bezierOffsetMaxDepth limits how many times a curve is halved while
+	// fitting its offset.
+	bezierOffsetMaxDepth = 16
+	// bezierCrossingMaxDepth limits how many times two curves are halved
+	// while looking for where they cross.
+	bezierCrossingMaxDepth = 40
+)
+
+// JoinStyle is the shape used to connect offset sides around a corner.
+type JoinStyle uint
+
+const (
+	JOIN_STYLE_MITER JoinStyle = iota
+	JOIN_STYLE_ROUND
+	JOIN_STYLE_BEVEL
+)
+
+// Join describes how offset sides are connected around the outside of a
+// corner. Sides on the inside of a corner are always trimmed where they meet.
+type Join struct {
+	style     JoinStyle
+	limit     float64
+	tolerance Length
+}
+
+// JoinMiter extends the offset sides until they meet in a point. If that
+// point would be more than \c limit times the offset distance from the
+// corner, the corner is beveled instead. Limits less than 1 are treated as 1.
+func JoinMiter(limit float64) Join {
+	return Join{
+		style: JOIN_STYLE_MITER,
+		limit: math.Max(limit, 1),
+	}
+}
+
+// JoinRound connects the offset sides with an arc around the corner. The arc
+// is approximated by segments that stray no more than \c tolerance from the
+// arc.
+func JoinRound(tolerance Length) Join {
+	return Join{
+		style:     JOIN_STYLE_ROUND,
+		tolerance: tolerance,
+	}
+}
+
+// JoinBevel connects the offset sides with a single segment.
+func JoinBevel() Join {
+	return Join{
+		style: JOIN_STYLE_BEVEL,
+	}
+}
+
+// Style returns the join style.
+func (j Join) Style() JoinStyle { return j.style }
+
+// OffsetPolygon grows the polygon outward by \c distance, or shrinks it
+// inward when \c distance is negative. Shrinking can split the polygon into
+// several polygons, or remove it entirely. The winding of the polygon is
+// ignored.
+//
+// The result uses the same format as \c BooleanPolygonPolygon:
+// counter-clockwise rings are outer boundaries and clockwise rings are holes.
+func OffsetPolygon(poly Polygon, distance Length, join Join) []Polygon {
+	return offsetRings(ringsFromPolygon(poly), distance, join)
+}
+
+// OffsetRegion grows the region outward by \c distance, or shrinks it inward
+// when \c distance is negative. Holes shrink as the region grows, and grow as
+// the region shrinks.
+func OffsetRegion(r Region, distance Length, join Join) MultiRegion {
+	return MultiRegionFromRings(offsetRings(r.Rings(), distance, join)...)
+}
+
+// offsetRings offsets every ring to its right by \c distance. Outer rings are
+// counter-clockwise and holes are clockwise, so the right of every ring is
+// away from the filled area.
+func offsetRings(rings []Polygon, distance Length, join Join) []Polygon {
+	// see http://www.angusj.com/clipper2/Docs/Units/Clipper.Offset/Classes/ClipperOffset/_Body.htm
+	raw := make([]Polygon, 0, len(rings))
+	for _, ring := range rings {
+		if r := offsetRing(ring, distance, join); len(r.Points()) > 2 {
+			raw = append(raw, r)
+		}
+	}
+
+	// The raw rings loop back on themselves around the inside of corners,
+	// and where the offset collapses. Those loops wind the wrong way, so
+	// keep only the positively wound areas.
+	return resolveRings(raw, FILL_RULE_POSITIVE)
+}
+
+// offsetRing moves every side of the ring \c distance to its right, and
+// connects the moved sides. The result usually crosses itself.
+func offsetRing(ring Polygon, distance Length, join Join) Polygon {
+	pts := simplifyRing(append([]Pt{}, ring.Points()...))
+	if len(pts) < 3 || IsZero(distance) {
+		return PolygonPt(pts...)
+	}
+
+	absd := distance
+	if absd < 0 {
+		absd = -absd
+	}
+	normal := func(v Vector) Vector {
+		i, j := v.Units()
+		return VectorIj(j, -i).Scale(distance)
+	}
+
+	n := len(pts)
+	out := make([]Pt, 0, n*3)
+	for h := 0; h < n; h++ {
+		prev, curr, next := pts[(h+n-1)%n], pts[h], pts[(h+1)%n]
+		d1, d2 := prev.VectorTo(curr).Normalize(), curr.VectorTo(next).Normalize()
+		n1, n2 := normal(d1), normal(d2)
+		a, b := curr.Add(n1), curr.Add(n2)
+
+		i1, j1 := d1.Units()
+		i2, j2 := d2.Units()
+		cross := i1*j2 - j1*i2
+		dot := d1.Dot(d2)
+
+		switch {
+		case IsZero(cross) && dot > 0:
+			// Straight through, nothing to join.
+			out = append(out, a)
+		case cross*distance > 0 || (IsZero(cross) && distance > 0):
+			// The corner turns away from the offset, so the gap
+			// between the moved sides needs to be filled.
+			out = append(out, offsetJoin(curr, n1, n2, cross, dot, absd, join)...)
+		default:
+			// The moved sides overlap. Connect them through the
+			// corner and let the loop be removed later.
+			out = append(out, a, curr, b)
+		}
+	}
+	return PolygonPt(out...)
+}
+
+// offsetJoin returns the points that fill the gap between the moved sides
+// around \c corner. \c n1 and \c n2 are the offsets of the sides before and
+// after the corner.
+func offsetJoin(corner Pt, n1, n2 Vector, cross, dot, absd Length, join Join) []Pt {
+	a, b := corner.Add(n1), corner.Add(n2)
+	switch join.style {
+	case JOIN_STYLE_MITER:
+		// The miter point is along the bisector of the normals.
+		cosHalf := Length(math.Sqrt(math.Max(float64(1+dot)/2, 0)))
+		if !IsZero(cosHalf) && 1/cosHalf <= Length(join.limit) {
+			bisector := n1.Add(n2).Normalize()
+			return []Pt{corner.Add(bisector.Scale(absd / cosHalf))}
+		}
+	case JOIN_STYLE_ROUND:
+		sweep := math.Acos(float64(Clamp(-1, dot, 1)))
+		if cross < 0 {
+			sweep = -sweep
+		} else if IsZero(cross) {
+			sweep = math.Pi
+		}
+
+		steps := arcFlattenSteps(absd, Radians(sweep), join.tolerance)
+		pts := make([]Pt, 0, steps+1)
+		pts = append(pts, a)
+		for h := 1; h < steps; h++ {
+			theta := Radians(sweep * float64(h) / float64(steps))
+			pts = append(pts, corner.Add(n1.Rotate(theta)))
+		}
+		return append(pts, b)
+	}
+	return []Pt{a, b}
+}
+
+// OffsetBezier moves the curve \c distance to its right, or to its left when
+// \c distance is negative. That is the same direction \c OffsetPolygon moves
+// the sides of a counter-clockwise polygon. The exact offset of a Bezier is
+// not a Bezier, so it is approximated with cubic curves that stray no more
+// than \c tolerance from the exact offset. Returns the curves and a bound on
+// the distance between the curves and the exact offset at the same value of
+// t.
+//
+// The curve is split at its inflections, at its points of greatest curvature,
+// and where the radius of curvature matches the distance, because the offset
+// turns back on itself there. Each piece is halved until its offset is
+// within tolerance. A piece that is still not within tolerance after being
+// halved many times is kept anyway, and the returned deviation is greater
+// than \c tolerance.
+//
+// Where the radius of curvature is smaller than the distance, the offset
+// runs backwards and forms a loop on the inside of the bend. The loop is
+// trimmed where the offsets on either side of it cross. A loop that is cut
+// short by the end of the curve doesn't cross, and is kept.
+//
+// A cusp reverses the direction of the curve, so the offsets on either side
+// of it do not meet. They are connected by curves that follow a half circle
+// around the cusp, like a round join.
+//
+// The tolerance must be greater than zero. Returns nil and a NaN deviation if
+// the tolerance, distance or curve are in error.
+func OffsetBezier(curve Bezier, distance, tolerance Length) ([]Bezier, Length) {
+	if _, err := distance.OrErr(); err != nil || !(tolerance > 0) {
+		return nil, Length(math.NaN())
+	}
+	for _, p := range curve.pts {
+		if _, err := p.OrErr(); err != nil {
+			return nil, Length(math.NaN())
+		}
+	}
+	if IsZero(distance) {
+		return []Bezier{curve}, 0
+	}
+
+	ts := []float64{0, 1}
+	ts = append(ts, curve.InflectionPts()...)
+	ts = append(ts, curve.MaxCurvatureTs()...)
+	ts = append(ts, bezierOffsetCuspTs(curve, distance)...)
+	if curve.CurveType() == BEZIER_CURVE_TYPE_CUSP {
+		ts = append(ts, bezierCuspT(curve))
+	}
+	sort.Float64s(ts)
+
+	absd := distance
+	if absd < 0 {
+		absd = -absd
+	}
+	var curves []Bezier
+	var sections []offsetSection
+	var deviation Length
+	for h := 1; h < len(ts); h++ {
+		lo, hi := ts[h-1], ts[h]
+		if IsZero(hi - lo) {
+			continue
+		}
+		_, rest := curve.SplitAtT(lo)
+		piece, _ := rest.SplitAtT((hi - lo) / (1 - lo))
+
+		var d Length
+		n := len(curves)
+		curves, d = appendOffsetBeziers(curves, piece, distance, tolerance, 0)
+		deviation = Maximum(deviation, d)
+		sections = append(sections, offsetSection{
+			begin:    n,
+			end:      len(curves),
+			backward: 1+float64(distance)*piece.CurvatureAtT(0.5) < 0,
+		})
+		if n == 0 || n == len(curves) {
+			continue
+		}
+
+		// The offsets only separate at a cusp, so close the gap with a
+		// half circle around the front of the cusp.
+		a, b := curves[n-1].pts[3], curves[n].pts[0]
+		if IsEqualPair(a, b) {
+			continue
+		}
+		cusp := piece.pts[0]
+		back, ok := bezierEndTangent(cusp, piece.pts[1], piece.pts[2], piece.pts[3])
+		if !ok {
+			continue
+		}
+		arc := ArcFromPts(a, cusp.Add(back.Scale(-absd)), b)
+		cap, d := BeziersFromArc(arc, tolerance)
+		if cap == nil {
+			continue
+		}
+		curves = append(curves[:n], append(cap, curves[n:]...)...)
+		sections[len(sections)-1].begin += len(cap)
+		sections[len(sections)-1].end += len(cap)
+		deviation = Maximum(deviation, d)
+	}
+	return trimOffsetLoops(curves, sections, tolerance), deviation
+}
+
+// offsetSection is the range of offset curves that came from the same piece
+// of the curve, and whether the offset of the piece runs backwards.
+type offsetSection struct {
+	begin, end int
+	backward   bool
+}
+
+// trimOffsetLoops removes the loops formed by the sections that run
+// backwards. The curves before and after each loop are cut where they cross,
+// and everything between them is removed.
+func trimOffsetLoops(curves []Bezier, sections []offsetSection, tolerance Length) []Bezier {
+	// Work from the end, so the ranges of the earlier sections don't move.
+	for h := len(sections) - 2; h > 0; h-- {
+		if !sections[h].backward || sections[h+1].backward {
+			continue
+		}
+		first := h
+		for first > 0 && sections[first-1].backward {
+			first--
+		}
+		if first == 0 {
+			break
+		}
+		before, after := sections[first-1], sections[h+1]
+		h = first
+		// The loop is usually closed by the curves next to it, so search
+		// outward from there.
+	search:
+		for i := before.end - 1; i >= before.begin; i-- {
+			for j := after.begin; j < after.end; j++ {
+				crossings := bezierCrossings(curves[i], curves[j], tolerance)
+				if len(crossings) == 0 {
+					continue
+				}
+				// The crossing closest to the loop.
+				best := crossings[0]
+				for _, c := range crossings[1:] {
+					if c[0]-c[1] > best[0]-best[1] {
+						best = c
+					}
+				}
+				head, _ := curves[i].SplitAtT(best[0])
+				_, tail := curves[j].SplitAtT(best[1])
+				tail.pts[0] = head.pts[3]
+				curves[i], curves[j] = head, tail
+				curves = append(curves[:i+1], curves[j:]...)
+				sections[first-1].end = i + 1
+				break search
+			}
+		}
+	}
+	return curves
+}
+
+// bezierCrossings returns the values of t on each curve where the curves
+// cross. The curves are halved while the boxes around their control points
+// overlap, until the boxes are smaller than \c tolerance, and then the
+// crossings are polished with Newton's method.
+func bezierCrossings(a, b Bezier, tolerance Length) [][2]float64 {
+	var crossings [][2]float64
+	var search func(pa, pb Bezier, a0, a1, b0, b1 float64, depth int)
+	search = func(pa, pb Bezier, a0, a1, b0, b1 float64, depth int) {
+		alx, amx, aly, amy := LimitsPts(pa.pts[:])
+		blx, bmx, bly, bmy := LimitsPts(pb.pts[:])
+		if amx < blx || bmx < alx || amy < bly || bmy < aly {
+			return
+		}
+		small := Maximum(amx-alx, amy-aly, bmx-blx, bmy-bly) <= tolerance
+		if small || depth >= bezierCrossingMaxDepth {
+			s, t, ok := polishCrossing(a, b, (a0+a1)/2, (b0+b1)/2)
+			if !ok {
+				return
+			}
+			for _, c := range crossings {
+				if math.Abs(c[0]-s) <= 1e-9 && math.Abs(c[1]-t) <= 1e-9 {
+					return
+				}
+			}
+			crossings = append(crossings, [2]float64{s, t})
+			return
+		}
+		pa1, pa2 := pa.SplitAtT(0.5)
+		pb1, pb2 := pb.SplitAtT(0.5)
+		am, bm := (a0+a1)/2, (b0+b1)/2
+		search(pa1, pb1, a0, am, b0, bm, depth+1)
+		search(pa1, pb2, a0, am, bm, b1, depth+1)
+		search(pa2, pb1, am, a1, b0, bm, depth+1)
+		search(pa2, pb2, am, a1, bm, b1, depth+1)
+	}
+	search(a, b, 0, 1, 0, 1, 0)
+	return crossings
+}
+
+// polishCrossing returns the values of t near \c s and \c t where the curves
+// are at the same point, using Newton's method. Returns false if the curves
+// don't meet there.
+func polishCrossing(a, b Bezier, s, t float64) (float64, float64, bool) {
+	// see https://en.wikipedia.org/wiki/Newton%27s_method#Systems_of_equations
+	adx, ady := a.x.FirstDerivative(), a.y.FirstDerivative()
+	bdx, bdy := b.x.FirstDerivative(), b.y.FirstDerivative()
+	for h := 0; h < rootIterations; h++ {
+		fi, fj := b.PtAtT(t).VectorTo(a.PtAtT(s)).Units()
+		ai, aj := adx.AtT(s), ady.AtT(s)
+		bi, bj := bdx.AtT(t), bdy.AtT(t)
+		det := bi*aj - ai*bj
+		if det == 0 {
+			break
+		}
+		ds := (float64(fi)*bj - bi*float64(fj)) / det
+		dt := (aj*float64(fi) - ai*float64(fj)) / det
+		s, t = s+ds, t+dt
+		if math.Abs(ds) <= 1e-15 && math.Abs(dt) <= 1e-15 {
+			break
+		}
+	}
+	if s < 0 || 1 < s || t < 0 || 1 < t || !IsEqualPair(a.PtAtT(s), b.PtAtT(t)) {
+		return s, t, false
+	}
+	return s, t, true
+}
+
+// appendOffsetBeziers fits the offset of the curve with a cubic that has the
+// same end points and end derivatives as the exact offset, halving the curve
+// when the fit is not within tolerance, and appends the fits to \c curves.
+func appendOffsetBeziers(curves []Bezier, piece Bezier, distance, tolerance Length, depth int) ([]Bezier, Length) {
+	if _, ok := bezierEndTangent(piece.pts[0], piece.pts[1], piece.pts[2], piece.pts[3]); !ok {
+		// A single point has no direction to offset.
+		return curves, 0
+	}
+	tangentAtT := func(t float64) Vector {
+		tangent, _ := piece.TangentAtT(t)
+		if IsZero(tangent.Magnitude()) {
+			// No direction at a cusp, so use the direction of the
+			// nearest control points.
+			if t < 0.5 {
+				tangent, _ = bezierEndTangent(piece.pts[0], piece.pts[1], piece.pts[2], piece.pts[3])
+			} else {
+				tangent, _ = bezierEndTangent(piece.pts[3], piece.pts[2], piece.pts[1], piece.pts[0])
+				tangent = tangent.Invert()
+			}
+		}
+		return tangent.Normalize()
+	}
+	offsetAtT := func(t float64) Pt {
+		i, j := tangentAtT(t).Units()
+		return piece.PtAtT(t).Add(VectorIj(j, -i).Scale(distance))
+	}
+	// The offset moves at (1 + distance * curvature) times the speed of the
+	// curve, which is undefined at a cusp, so estimate it there instead.
+	derivativeAtT := func(t float64) Vector {
+		tangent, _ := piece.TangentAtT(t)
+		if k := piece.CurvatureAtT(t); !math.IsNaN(k) && !IsZero(tangent.Magnitude()) {
+			return tangent.Scale(1 + distance*Length(k))
+		}
+		const h = 1e-6
+		if t < 0.5 {
+			return offsetAtT(t).VectorTo(offsetAtT(t + h)).Scale(1 / h)
+		}
+		return offsetAtT(t - h).VectorTo(offsetAtT(t)).Scale(1 / h)
+	}
+
+	p1, p4 := offsetAtT(0), offsetAtT(1)
+	p2 := p1.Add(derivativeAtT(0).Scale(1.0 / 3))
+	p3 := p4.Add(derivativeAtT(1).Scale(-1.0 / 3))
+	fit := BezierPt(p1, p2, p3, p4)
+	deviation := offsetDeviation(piece, fit, tangentAtT, offsetAtT)
+	if deviation <= tolerance || depth >= bezierOffsetMaxDepth {
+		return append(curves, fit), deviation
+	}
+	first, second := piece.SplitAtT(0.5)
+	curves, d1 := appendOffsetBeziers(curves, first, distance, tolerance, depth+1)
+	curves, d2 := appendOffsetBeziers(curves, second, distance, tolerance, depth+1)
+	return curves, Maximum(d1, d2)
+}
+
+// offsetDeviation returns a bound on the distance between the fit and the
+// exact offset of the piece at the same value of t. The difference is split
+// into the parts along and across the tangent of the piece. Each part is a
+// polynomial divided by the speed of the piece, so it is largest at the ends
+// or where its derivative is zero, and the bound combines the largest of
+// each.
+func offsetDeviation(piece, fit Bezier, tangentAtT func(float64) Vector, offsetAtT func(float64) Pt) Length {
+	dx, dy := PolynomialNFrom(piece.x).FirstDerivative(), PolynomialNFrom(piece.y).FirstDerivative()
+	// The derivative is zero at the end of a piece that stops at a cusp.
+	// Dividing out that root keeps the direction, and avoids a repeated
+	// root at the end of the range.
+	for h, root := range []PolynomialN{PolynomialNCoefficients(1, 0), PolynomialNCoefficients(1, -1)} {
+		if IsZero(piece.pts[h*3].VectorTo(piece.pts[1+h]).Magnitude()) {
+			dx, _ = dx.Divide(root)
+			dy, _ = dy.Divide(root)
+		}
+	}
+	px := PolynomialNFrom(fit.x).Sub(PolynomialNFrom(piece.x))
+	py := PolynomialNFrom(fit.y).Sub(PolynomialNFrom(piece.y))
+	speed2 := dx.Mul(dx).Add(dy.Mul(dy))
+	ts := []float64{0, 1}
+	for _, part := range []PolynomialN{
+		px.Mul(dx).Add(py.Mul(dy)),
+		px.Mul(dy).Sub(py.Mul(dx)),
+	} {
+		// The derivative of part/sqrt(speed2) is zero where
+		// 2 part' speed2 - part speed2' is zero.
+		critical := part.FirstDerivative().Mul(speed2).Scale(2).Sub(part.Mul(speed2.FirstDerivative()))
+		for _, root := range critical.RealRoots(0, 1) {
+			ts = append(ts, root.T)
+		}
+	}
+	var along, across Length
+	for _, t := range ts {
+		ti, tj := tangentAtT(t).Units()
+		ei, ej := offsetAtT(t).VectorTo(fit.PtAtT(t)).Units()
+		along = Maximum(along, Length(math.Abs(float64(ei*ti+ej*tj))))
+		across = Maximum(across, Length(math.Abs(float64(ei*tj-ej*ti))))
+	}
+	return Length(math.Hypot(float64(along), float64(across)))
+}
+
+// bezierCuspT returns the value of \c t where the curve stops, which is the
+// cusp of a curve with the type BEZIER_CURVE_TYPE_CUSP.
+func bezierCuspT(curve Bezier) float64 {
+	dx, dy := curve.x.FirstDerivative(), curve.y.FirstDerivative()
+	best, bestSpeed := 0.5, math.Inf(1)
+	for _, t := range append(dx.Roots(), dy.Roots()...) {
+		if t < 0 || 1 < t {
+			continue
+		}
+		if speed := math.Hypot(dx.AtT(t), dy.AtT(t)); speed < bestSpeed {
+			best, bestSpeed = t, speed
+		}
+	}
+	return best
+}
+
+// bezierOffsetCuspTs returns the values of \c t where the radius of curvature
+// is the same as the distance, on the side of the offset. The offset stops and
+// reverses direction at those points.
+func bezierOffsetCuspTs(curve Bezier, distance Length) []float64 {
+	// The offset cusps where 1 + distance * k = 0, so where
+	// distance * C = -S^(3/2) with k = C / S^(3/2). Squaring gives
+	// distance^2 C^2 - S^3 = 0, and the sign of C picks the side.
+	dx, dy := curve.x.FirstDerivative(), curve.y.FirstDerivative()
+	ddx, ddy := dx.FirstDerivative(), dy.FirstDerivative()
+	x1, y1 := dx.Coefficients(), dy.Coefficients()
+	x2, y2 := ddx.Coefficients(), ddy.Coefficients()
+	c := polynomialAdd(polynomialMul(x1, y2), polynomialMul([]float64{-1}, polynomialMul(y1, x2)))
+	s := polynomialAdd(polynomialMul(x1, x1), polynomialMul(y1, y1))
+	d2 := float64(distance * distance)
+	coeffs := polynomialAdd(
+		polynomialMul([]float64{d2}, polynomialMul(c, c)),
+		polynomialMul([]float64{-1}, polynomialMul(s, polynomialMul(s, s))),
+	)
+
+	// C and S are both zero at a cusp of the curve, which adds roots that
+	// don't satisfy the unsquared equation.
+	var ts []float64
+	for _, t := range polynomialRoots(coeffs, 0, 1) {
+		if k := curve.CurvatureAtT(t); math.Abs(1+float64(distance)*k) < 1e-6 {
+			ts = append(ts, t)
+		}
+	}
+	return ts
+}
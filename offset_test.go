@@ -0,0 +1,302 @@
+package figuring
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOffsetPolygon(t *testing.T) {
+	square := PolygonPt(PtXy(0, 0), PtXy(10, 0), PtXy(10, 10), PtXy(0, 10))
+	ell := PolygonPt(PtOrig, PtXy(20, 0), PtXy(20, 10), PtXy(10, 10), PtXy(10, 30), PtXy(0, 30))
+	// Two squares joined by a narrow neck.
+	dumbbell := PolygonPt(
+		PtXy(0, 0), PtXy(10, 0), PtXy(10, 4), PtXy(20, 4), PtXy(20, 0), PtXy(30, 0),
+		PtXy(30, 10), PtXy(20, 10), PtXy(20, 6), PtXy(10, 6), PtXy(10, 10), PtXy(0, 10),
+	)
+
+	offsetTests := []struct {
+		a        Polygon
+		distance Length
+		join     Join
+		rings    int
+		area     Length
+	}{
+		{
+			//0
+			square, 1, JoinMiter(2),
+			1, 144,
+		}, {
+			square.Reverse(), 1, JoinMiter(2),
+			1, 144,
+		}, {
+			square, 1, JoinBevel(),
+			1, 142,
+		}, {
+			square, 1, JoinMiter(1.1),
+			1, 142,
+		}, {
+			square, -1, JoinMiter(2),
+			1, 64,
+		}, {
+			//5
+			square, -1, JoinRound(0.001),
+			1, 64,
+		}, {
+			square, -6, JoinMiter(2),
+			0, 0,
+		}, {
+			square, 0, JoinMiter(2),
+			1, 100,
+		}, {
+			ell, 1, JoinMiter(2),
+			1, 504,
+		}, {
+			ell, -1, JoinMiter(2),
+			1, 304,
+		}, {
+			//10
+			dumbbell, -0.5, JoinMiter(2),
+			1, 81*2 + 11*1,
+		}, {
+			dumbbell, -1.5, JoinMiter(2),
+			2, 49 * 2,
+		}, {
+			dumbbell, 1, JoinMiter(2),
+			1, 12*32 - 2*8*4,
+		},
+	}
+	for h, test := range offsetTests {
+		a, distance, join := test.a, test.distance, test.join
+		rings := OffsetPolygon(a, distance, join)
+		if len(rings) != test.rings {
+			t.Errorf("[%d]OffsetPolygon(%v, %f, %d) (length) failed. %d != %d. %v",
+				h, a, distance, join.Style(), len(rings), test.rings, rings)
+		}
+		var area Length
+		for _, ring := range rings {
+			if winding := ring.Winding(); winding != WINDING_COUNTERCLOCKWISE {
+				t.Errorf("[%d]OffsetPolygon(%v, %f, %d) (winding) failed. %d != %d",
+					h, a, distance, join.Style(), winding, WINDING_COUNTERCLOCKWISE)
+			}
+			area += ring.Area()
+		}
+		if math.Abs(float64(area-test.area)) > 0.01 {
+			t.Errorf("[%d]OffsetPolygon(%v, %f, %d) (area) failed. %f != %f. %v",
+				h, a, distance, join.Style(), area, test.area, rings)
+		}
+	}
+
+	roundTests := []struct {
+		a         Polygon
+		distance  Length
+		tolerance Length
+	}{
+		{square, 1, 0.01},
+		{square, 2, 0.001},
+		{TriangleEquilateral.Scale(VectorIj(10, 10)), 0.5, 0.005},
+	}
+	for h, test := range roundTests {
+		a, distance, tolerance := test.a, test.distance, test.tolerance
+		rings := OffsetPolygon(a, distance, JoinRound(tolerance))
+		if len(rings) != 1 {
+			t.Fatalf("[%d]OffsetPolygon(%v, %f, JoinRound(%f)) (length) failed. %d != %d",
+				h, a, distance, tolerance, len(rings), 1)
+		}
+		// A round offset of a convex polygon adds a full circle to the area.
+		exact := a.Area() + a.Perimeter()*distance + math.Pi*distance*distance
+		area := rings[0].Area()
+		if area > exact || exact-area > a.Perimeter()*tolerance {
+			t.Errorf("[%d]OffsetPolygon(%v, %f, JoinRound(%f)) (area) failed. %f != %f",
+				h, a, distance, tolerance, area, exact)
+		}
+		for _, p := range rings[0].Points() {
+			if c := a.ContainsPt(p, FILL_RULE_NON_ZERO); c != CONTAINMENT_OUTSIDE {
+				t.Errorf("[%d]OffsetPolygon(%v, %f, JoinRound(%f)) (containment) failed. %v %d",
+					h, a, distance, tolerance, p, c)
+			}
+		}
+	}
+
+	washer := RegionPolygon(square, PolygonPt(PtXy(3, 3), PtXy(7, 3), PtXy(7, 7), PtXy(3, 7)))
+	regionTests := []struct {
+		a        Region
+		distance Length
+		regions  int
+		holes    int
+		area     Length
+	}{
+		{washer, 1, 1, 1, 140},
+		{washer, 2.5, 1, 0, 225},
+		{washer, -1, 1, 1, 64 - 36},
+		{washer, -0.5, 1, 1, 81 - 25},
+		{washer, -2, 0, 0, 0},
+	}
+	for h, test := range regionTests {
+		a, distance := test.a, test.distance
+		mr := OffsetRegion(a, distance, JoinMiter(2))
+		regions := mr.Regions()
+		if len(regions) != test.regions {
+			t.Errorf("[%d]OffsetRegion(%v, %f) (length) failed. %d != %d. %v",
+				h, a, distance, len(regions), test.regions, mr)
+		}
+		var holes int
+		for _, r := range regions {
+			holes += len(r.Holes())
+		}
+		if holes != test.holes {
+			t.Errorf("[%d]OffsetRegion(%v, %f) (holes) failed. %d != %d. %v",
+				h, a, distance, holes, test.holes, mr)
+		}
+		if area := mr.Area(); math.Abs(float64(area-test.area)) > 0.01 {
+			t.Errorf("[%d]OffsetRegion(%v, %f) (area) failed. %f != %f. %v",
+				h, a, distance, area, test.area, mr)
+		}
+	}
+}
+
+func TestOffsetBezier(t *testing.T) {
+	line := BezierPt(PtXy(0, 0), PtXy(1, 0), PtXy(2, 0), PtXy(3, 0))
+	plain := BezierPt(PtXy(10, 10), PtXy(10, 40), PtXy(50, 45), PtXy(45, -10))
+	cusp := BezierPt(PtXy(0, 0), PtXy(4, 4), PtXy(0, 4), PtXy(4, 0))
+	inflection := BezierPt(PtXy(0, 0), PtXy(3, 3), PtXy(6, -3), PtXy(9, 0))
+	loop := BezierPt(PtXy(0, 0), PtXy(6, 4), PtXy(-2, 4), PtXy(4, 0))
+	if ct := cusp.CurveType(); ct != BEZIER_CURVE_TYPE_CUSP {
+		t.Fatalf("(%v).CurveType() failed. %d != %d", cusp, ct, BEZIER_CURVE_TYPE_CUSP)
+	}
+	if ct := loop.CurveType(); ct != BEZIER_CURVE_TYPE_LOOP {
+		t.Fatalf("(%v).CurveType() failed. %d != %d", loop, ct, BEZIER_CURVE_TYPE_LOOP)
+	}
+
+	offsetTests := []struct {
+		a          Bezier
+		distance   Length
+		tolerance  Length
+		curves     int
+		begin, end Pt
+	}{
+		{
+			//0
+			line, 1, 0.01,
+			1, PtXy(0, -1), PtXy(3, -1),
+		}, {
+			line, -1, 0.01,
+			1, PtXy(0, 1), PtXy(3, 1),
+		}, {
+			plain, 1, 0.01,
+			5, PtXy(11, 10), PtXy(44.004106794, -9.909464254),
+		}, {
+			plain, 3, 0.001,
+			11, PtXy(13, 10), PtXy(42.012320381, -9.728392762),
+		}, {
+			cusp, 1, 0.01,
+			12, PtXy(0.707106781, -0.707106781), PtXy(3.292893219, -0.707106781),
+		}, {
+			//5
+			cusp, -1, 0.01,
+			10, PtXy(-0.707106781, 0.707106781), PtXy(4.707106781, 0.707106781),
+		}, {
+			inflection, 3, 0.01,
+			9, PtXy(2.121320344, -2.121320344), PtXy(11.121320344, -2.121320344),
+		}, {
+			loop, -1, 0.01,
+			9, PtXy(-0.554700196, 0.832050294), PtXy(4.554700196, 0.832050294),
+		},
+	}
+	for h, test := range offsetTests {
+		a, distance, tolerance := test.a, test.distance, test.tolerance
+		curves, deviation := OffsetBezier(a, distance, tolerance)
+		if len(curves) != test.curves {
+			t.Errorf("[%d]OffsetBezier(%v, %f, %f) (length) failed. %d != %d",
+				h, a, distance, tolerance, len(curves), test.curves)
+		}
+		if len(curves) == 0 {
+			continue
+		}
+		if deviation > tolerance {
+			t.Errorf("[%d]OffsetBezier(%v, %f, %f) (deviation) failed. %f > %f",
+				h, a, distance, tolerance, deviation, tolerance)
+		}
+		if begin := curves[0].Begin(); !IsEqualPair(begin, test.begin) {
+			t.Errorf("[%d]OffsetBezier(%v, %f, %f) (begin) failed. %v != %v",
+				h, a, distance, tolerance, begin, test.begin)
+		}
+		if end := curves[len(curves)-1].End(); !IsEqualPair(end, test.end) {
+			t.Errorf("[%d]OffsetBezier(%v, %f, %f) (end) failed. %v != %v",
+				h, a, distance, tolerance, end, test.end)
+		}
+		absd := Length(math.Abs(float64(distance)))
+		for i, curve := range curves {
+			if i > 0 && !IsEqualPair(curves[i-1].End(), curve.Begin()) {
+				t.Errorf("[%d][%d]OffsetBezier(%v, %f, %f) (continuity) failed. %v != %v",
+					h, i, a, distance, tolerance, curves[i-1].End(), curve.Begin())
+			}
+			// No point strays farther from the curve than the distance.
+			for j := 0; j <= 20; j++ {
+				p := curve.PtAtT(float64(j) / 20)
+				if _, _, d := a.ClosestPt(p); d > absd+tolerance {
+					t.Errorf("[%d][%d][%d]OffsetBezier(%v, %f, %f) (distance) failed. %v %f > %f",
+						h, i, j, a, distance, tolerance, p, d, absd+tolerance)
+				}
+			}
+		}
+	}
+
+	// The gap at a cusp is closed around the front of the cusp.
+	front := PtXy(2, 4)
+	curves, _ := OffsetBezier(cusp, 1, 0.01)
+	best := Length(math.Inf(1))
+	for _, curve := range curves {
+		_, _, d := curve.ClosestPt(front)
+		best = Minimum(best, d)
+	}
+	if best > 0.01 {
+		t.Errorf("OffsetBezier(%v, 1, 0.01) (cusp) failed. %v is %f away", cusp, front, best)
+	}
+
+	// The loops on the inside of a tight bend are trimmed.
+	bend := BezierPt(PtXy(0, 0), PtXy(8, 0), PtXy(8, 2), PtXy(0, 2))
+	for h, distance := range []Length{-0.5, -0.8} {
+		curves, deviation := OffsetBezier(bend, distance, 0.001)
+		if len(curves) == 0 || deviation > 0.001 {
+			t.Fatalf("[%d]OffsetBezier(%v, %f, 0.001) (bend) failed. %v, %f",
+				h, bend, distance, curves, deviation)
+		}
+		absd := Length(math.Abs(float64(distance)))
+		for i, curve := range curves {
+			for j := 0; j <= 20; j++ {
+				p := curve.PtAtT(float64(j) / 20)
+				if _, _, d := bend.ClosestPt(p); d < absd-0.001 {
+					t.Errorf("[%d][%d][%d]OffsetBezier(%v, %f, 0.001) (loop) failed. %v %f < %f",
+						h, i, j, bend, distance, p, d, absd-0.001)
+				}
+			}
+			for j := i + 2; j < len(curves); j++ {
+				if crossings := bezierCrossings(curve, curves[j], 0.001); len(crossings) > 0 {
+					t.Errorf("[%d][%d][%d]OffsetBezier(%v, %f, 0.001) (crossing) failed. %v",
+						h, i, j, bend, distance, crossings)
+				}
+			}
+		}
+	}
+
+	if curves, deviation := OffsetBezier(plain, 0, 0.01); len(curves) != 1 || curves[0] != plain || deviation != 0 {
+		t.Errorf("OffsetBezier(%v, 0, 0.01) failed. %v, %f", plain, curves, deviation)
+	}
+	errorTests := []struct {
+		a         Bezier
+		distance  Length
+		tolerance Length
+	}{
+		{plain, 1, 0},
+		{plain, 1, -1},
+		{plain, Length(math.NaN()), 0.01},
+		{BezierPt(PtXy(0, 0), PtNaN, PtXy(1, 1), PtXy(2, 2)), 1, 0.01},
+	}
+	for h, test := range errorTests {
+		curves, deviation := OffsetBezier(test.a, test.distance, test.tolerance)
+		if curves != nil || !math.IsNaN(float64(deviation)) {
+			t.Errorf("[%d]OffsetBezier(%v, %f, %f) failed. %v, %f",
+				h, test.a, test.distance, test.tolerance, curves, deviation)
+		}
+	}
+}
@@ -12,12 +12,66 @@ type OrderedPtser interface {
 	Points() []Pt
 }
 
+// HullPtser is an interface for the types that provide points whose convex
+// hull contains the shape. Shapes made of points, such as polygons and Bezier
+// curves, return their points. Curved shapes, such as circles, return points
+// no more than a tolerance outside of the shape. See \c ConvexHullTolerance.
+type HullPtser interface {
+	HullPts(tolerance Length) []Pt
+}
+
 // BoundingBoxer is the interface set of all types that provide a BoundingBox()
 // interface.
 type BoundingBoxer interface {
 	BoundingBox() Rectangle
 }
 
+// Winding is the direction the points of a closed shape travel around the
+// inside of that shape.
+type Winding uint
+
+const (
+	WINDING_NONE Winding = iota
+	WINDING_CLOCKWISE
+	WINDING_COUNTERCLOCKWISE
+)
+
+// Containment is the relationship between a point and a closed shape.
+type Containment uint
+
+const (
+	CONTAINMENT_OUTSIDE Containment = iota
+	CONTAINMENT_INSIDE
+	CONTAINMENT_BOUNDARY
+)
+
+// FillRule determines which points are considered inside a polygon that
+// overlaps itself.
+type FillRule uint
+
+const (
+	// FILL_RULE_EVEN_ODD treats a point as inside when a ray from the
+	// point crosses the polygon an odd number of times.
+	FILL_RULE_EVEN_ODD FillRule = iota
+	// FILL_RULE_NON_ZERO treats a point as inside when the polygon winds
+	// around the point a non-zero number of times.
+	FILL_RULE_NON_ZERO
+	// FILL_RULE_POSITIVE treats a point as inside when the polygon winds
+	// counter-clockwise around the point more often than clockwise.
+	FILL_RULE_POSITIVE
+)
+
+// isFilled tests if a winding number is inside based on the fill rule.
+func (rule FillRule) isFilled(wn int) bool {
+	switch rule {
+	case FILL_RULE_NON_ZERO:
+		return wn != 0
+	case FILL_RULE_POSITIVE:
+		return wn > 0
+	}
+	return wn%2 != 0
+}
+
 // Rectangle represents an axis aligned rectangle. The resulting rectangle will
 // always be aligned with the X and Y axis.
 type Rectangle struct {
@@ -82,9 +136,45 @@ func RectangleAppend(r1, r2 Rectangle) Rectangle {
 	}
 }
 
+// Area returns the unsigned area of the rectangle.
+func (r Rectangle) Area() Length {
+	w, h := r.Dims()
+	return w * h
+}
+
+// Centroid returns the center of the rectangle.
+func (r Rectangle) Centroid() Pt {
+	v := r.pts[0].VectorTo(r.pts[1]).Scale(Half)
+	return r.pts[0].Add(v)
+}
+
+// ContainsPt returns if \c p is inside, outside, or on the boundary of the
+// rectangle.
+func (r Rectangle) ContainsPt(p Pt) Containment {
+	min, max := r.MinPt(), r.MaxPt()
+	x, y := p.XY()
+	xedge := IsEqual(x, min.X()) || IsEqual(x, max.X())
+	yedge := IsEqual(y, min.Y()) || IsEqual(y, max.Y())
+	xin := min.X() < x && x < max.X()
+	yin := min.Y() < y && y < max.Y()
+	// Points within tolerance of a side are on the boundary, even when they
+	// are also inside, like \c Polygon.ContainsPt.
+	switch {
+	case (xedge && (yin || yedge)) || (yedge && xin):
+		return CONTAINMENT_BOUNDARY
+	case xin && yin:
+		return CONTAINMENT_INSIDE
+	}
+	return CONTAINMENT_OUTSIDE
+}
+
 func (r Rectangle) Dims() (Length, Length) {
 	return r.pts[0].VectorTo(r.pts[1]).Units()
 }
+
+// HullPts implements the HullPtser interface. The points are the corners.
+func (r Rectangle) HullPts(tolerance Length) []Pt { return r.Points() }
+
 func (r Rectangle) Height() Length {
 	_, h := r.Dims()
 	return h
@@ -107,6 +197,10 @@ func (r Rectangle) OrErr() (Rectangle, *FloatingPointError) {
 	return r, nil
 }
 func (r Rectangle) Points() []Pt { return r.pts[:] }
+
+// Normalize returns the rectangle as a counter-clockwise polygon. The
+// rectangle itself cannot change winding, as it is defined by two points.
+func (r Rectangle) Normalize() Polygon { return PolygonFromRectangle(r) }
 func (r Rectangle) Sides() []Segment {
 	minmax, maxmin := PtXy(r.pts[0].X(), r.pts[1].Y()), PtXy(r.pts[1].X(), r.pts[0].Y())
 	return []Segment{
@@ -116,6 +210,10 @@ func (r Rectangle) Sides() []Segment {
 		SegmentPt(maxmin, r.pts[0]),
 	}
 }
+
+// SignedArea returns the area of the rectangle, signed based on the winding
+// of \c Sides(). Sides are clockwise, so a non-empty rectangle is negative.
+func (r Rectangle) SignedArea() Length { return -r.Area() }
 func (r Rectangle) String() string {
 	minmax, maxmin := PtXy(r.pts[0].X(), r.pts[1].Y()), PtXy(r.pts[1].X(), r.pts[0].Y())
 	return fmt.Sprintf("Rectangle[ Polygon(%v, %v, %v, %v) ]",
@@ -126,6 +224,9 @@ func (r Rectangle) Width() Length {
 	return w
 }
 
+// Winding returns the winding of the rectangle, based on \c Sides().
+func (r Rectangle) Winding() Winding { return windingFromArea(r.SignedArea()) }
+
 // ClipToRectangleSegment Clips the provided provided segment, keeping only the
 // parts of the segment inside the rectangle. Returns an empty slice if the
 // segment doesn't exist inside the rectangle.
@@ -144,23 +245,20 @@ func ClipToRectangleSegment(a Rectangle, b Segment) []Segment {
 	q2, q4 := max.X()-pnt.X(), max.Y()-pnt.Y()
 
 	posarr, negarr := []Length{1}, []Length{0}
-
-	r1, r2 := q1/p1, q2/p2
-	if p1 < 0 {
-		negarr = append(negarr, r1)
-		posarr = append(posarr, r2)
-	} else {
-		negarr = append(negarr, r2)
-		posarr = append(posarr, r1)
+	clip := func(p, q Length) bool {
+		switch {
+		case p == 0:
+			// Parallel to the side, so either entirely inside or outside.
+			return q >= 0
+		case p < 0:
+			negarr = append(negarr, q/p)
+		default:
+			posarr = append(posarr, q/p)
+		}
+		return true
 	}
-
-	r3, r4 := q3/p3, q4/p4
-	if p3 < 0 {
-		negarr = append(negarr, r3)
-		posarr = append(posarr, r4)
-	} else {
-		negarr = append(negarr, r4)
-		posarr = append(posarr, r3)
+	if !clip(p1, q1) || !clip(p2, q2) || !clip(p3, q3) || !clip(p4, q4) {
+		return nil
 	}
 
 	rn1, rn2 := Maximum(negarr...), Minimum(posarr...)
@@ -176,6 +274,86 @@ func ClipToRectangleSegment(a Rectangle, b Segment) []Segment {
 	}
 }
 
+// ConvexHullPts returns the smallest convex polygon that contains all of
+// \c pts. The polygon is counter-clockwise, starting from the point with the
+// lowest X (and then Y) value. Duplicate points, and points along the sides
+// of the hull, are not included.
+//
+// Degenerate inputs produce degenerate polygons: no points results in an
+// empty polygon, a single distinct point results in a one point polygon, and
+// collinear points result in a two point polygon of the extremes. If any
+// point is NaN or Inf, the returned polygon contains only that point, so the
+// error can be retrieved with \c OrErr().
+func ConvexHullPts(pts ...Pt) Polygon {
+	// see https://en.wikibooks.org/wiki/Algorithm_Implementation/Geometry/Convex_hull/Monotone_chain
+	for _, p := range pts {
+		if _, err := p.OrErr(); err != nil {
+			return PolygonPt(p)
+		}
+	}
+
+	sorted := make([]Pt, len(pts))
+	copy(sorted, pts)
+	SortPts(sorted)
+	uniq := make([]Pt, 0, len(sorted))
+	for _, p := range sorted {
+		if len(uniq) == 0 || !IsEqualPair(uniq[len(uniq)-1], p) {
+			uniq = append(uniq, p)
+		}
+	}
+	if len(uniq) < 3 {
+		return PolygonPt(uniq...)
+	}
+
+	isNotLeft := func(a, b, c Pt) bool {
+		cross := crossPts(a, b, c)
+		return cross < 0 || IsZero(cross)
+	}
+
+	hull := make([]Pt, 0, len(uniq)+1)
+	for _, p := range uniq {
+		for len(hull) > 1 && isNotLeft(hull[len(hull)-2], hull[len(hull)-1], p) {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	lower := len(hull) + 1
+	for h := len(uniq) - 2; h >= 0; h-- {
+		p := uniq[h]
+		for len(hull) >= lower && isNotLeft(hull[len(hull)-2], hull[len(hull)-1], p) {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	return PolygonPt(hull[:len(hull)-1]...)
+}
+
+// ConvexHull returns the convex hull of the points of all the provided
+// shapes. For Bezier curves, the hull of the control points also contains
+// the curve. Shapes without points, such as circles, need
+// \c ConvexHullTolerance. See \c ConvexHullPts for how degenerate inputs are
+// handled.
+func ConvexHull(shapes ...OrderedPtser) Polygon {
+	var pts []Pt
+	for _, shape := range shapes {
+		pts = append(pts, shape.Points()...)
+	}
+	return ConvexHullPts(pts...)
+}
+
+// ConvexHullTolerance returns the convex hull of the hull points of all the
+// provided shapes. The shapes can be mixed, such as circles with polygons and
+// Bezier curves, and the hull is no more than \c tolerance outside of the
+// circles. See \c HullPtser, and \c ConvexHullPts for how degenerate inputs
+// are handled.
+func ConvexHullTolerance(tolerance Length, shapes ...HullPtser) Polygon {
+	var pts []Pt
+	for _, shape := range shapes {
+		pts = append(pts, shape.HullPts(tolerance)...)
+	}
+	return ConvexHullPts(pts...)
+}
+
 // Unit objects, including triangles and rectangles.
 var (
 	Half          = Length(0.5)
@@ -209,6 +387,15 @@ func PolygonFromRectangle(r Rectangle) Polygon {
 	)
 }
 
+// Area returns the unsigned area of the polygon. Assumes the polygon does not
+// intersect itself.
+func (poly Polygon) Area() Length {
+	a := poly.SignedArea()
+	if a < 0 {
+		return -a
+	}
+	return a
+}
 func (poly Polygon) Angles() []Radians {
 	angles := make([]Radians, 0, len(poly.pts))
 	sides := poly.Sides()
@@ -228,6 +415,131 @@ func (poly Polygon) Angles() []Radians {
 	}
 	return angles
 }
+
+// BoundingBox returns an axis-aligned rectangle that encompasses all the
+// points of the polygon.
+func (poly Polygon) BoundingBox() Rectangle {
+	if len(poly.pts) == 0 {
+		return RectanglePt(PtNaN, PtNaN)
+	}
+	lx, mx, ly, my := LimitsPts(poly.pts)
+	return RectanglePt(PtXy(lx, ly), PtXy(mx, my))
+}
+
+// Centroid returns the center of mass of the polygon. Returns \c PtNaN when
+// the polygon has no area.
+func (poly Polygon) Centroid() Pt {
+	// see https://en.wikipedia.org/wiki/Centroid#Of_a_polygon
+	if len(poly.pts) < 3 {
+		return PtNaN
+	}
+
+	// Work relative to the first point to limit the size of the products.
+	origin := poly.pts[0]
+	var area, cx, cy Length
+	for h := 1; h < len(poly.pts)-1; h++ {
+		ax, ay := origin.VectorTo(poly.pts[h]).Units()
+		bx, by := origin.VectorTo(poly.pts[h+1]).Units()
+		cross := ax*by - bx*ay
+		area += cross
+		cx += (ax + bx) * cross
+		cy += (ay + by) * cross
+	}
+	if IsZero(area) {
+		return PtNaN
+	}
+	return origin.Add(VectorIj(cx/(3*area), cy/(3*area)))
+}
+
+// ClosestPt returns the value of \c t, the point, and the distance of the
+// point on the sides of the polygon closest to \c p. The whole part of \c t
+// is the index of the side in \c Sides, and the fraction is how far along
+// that side the point is.
+func (poly Polygon) ClosestPt(p Pt) (float64, Pt, Length) {
+	if len(poly.pts) == 0 {
+		return math.NaN(), PtNaN, Length(math.NaN())
+	}
+	bestT, bestPt, bestD := 0.0, poly.pts[0], Length(math.Inf(1))
+	for h, side := range poly.Sides() {
+		if t, c, d := side.ClosestPt(p); d < bestD {
+			bestT, bestPt, bestD = float64(h)+t, c, d
+		}
+	}
+	return bestT, bestPt, bestD
+}
+
+// ContainsPt returns if \c p is inside, outside, or on the boundary of the
+// polygon. Points within tolerance of a side are on the boundary. \c rule
+// decides the inside of polygons that overlap themselves.
+func (poly Polygon) ContainsPt(p Pt, rule FillRule) Containment {
+	if len(poly.pts) == 0 {
+		return CONTAINMENT_OUTSIDE
+	}
+	if _, err := p.OrErr(); err != nil {
+		return CONTAINMENT_OUTSIDE
+	}
+	for _, side := range poly.Sides() {
+		if isPtOnSegment(p, side) {
+			return CONTAINMENT_BOUNDARY
+		}
+	}
+
+	if rule.isFilled(poly.WindingNumber(p)) {
+		return CONTAINMENT_INSIDE
+	}
+	return CONTAINMENT_OUTSIDE
+}
+
+// Normalize returns the polygon with a counter-clockwise winding. Polygons
+// that are already counter-clockwise, or that have no winding, are returned
+// unchanged.
+func (poly Polygon) Normalize() Polygon {
+	if poly.Winding() == WINDING_CLOCKWISE {
+		return poly.Reverse()
+	}
+	return poly
+}
+
+// HullPts implements the HullPtser interface. The points are the polygon's.
+func (poly Polygon) HullPts(tolerance Length) []Pt { return poly.Points() }
+
+// IsConvex returns true if every corner of the polygon turns in the same
+// direction and the sides travel around the polygon exactly once. Collinear
+// points are ignored. Polygons without any area are not convex.
+func (poly Polygon) IsConvex() bool {
+	if poly.Winding() == WINDING_NONE {
+		return false
+	}
+
+	var (
+		sign    Length
+		turning Radians
+	)
+	sides := poly.Sides()
+	prev := sides[len(sides)-1]
+	for h := len(sides) - 1; IsZero(prev.Length()); h-- {
+		prev = sides[h-1]
+	}
+	for _, curr := range sides {
+		if IsZero(curr.Length()) {
+			continue
+		}
+		cross := crossPts(prev.Begin(), prev.End(), curr.End())
+		if !IsZero(cross) {
+			if sign != 0 && Signbit(sign) != Signbit(cross) {
+				return false
+			}
+			sign = cross
+		}
+		turn := (curr.Angle() - prev.Angle()).Normalize()
+		if turn > math.Pi {
+			turn -= 2 * math.Pi
+		}
+		turning += turn
+		prev = curr
+	}
+	return IsEqual(math.Abs(float64(turning)), 2*math.Pi)
+}
 func (poly Polygon) Perimeter() Length {
 	var sum Length
 	for _, side := range poly.Sides() {
@@ -248,6 +560,20 @@ func (poly Polygon) OrErr() (Polygon, *FloatingPointError) {
 	}
 	return poly, err
 }
+
+// Reverse returns the polygon with the order of the points reversed, keeping
+// the first point the same.
+func (poly Polygon) Reverse() Polygon {
+	if len(poly.pts) == 0 {
+		return poly
+	}
+	pts := make([]Pt, len(poly.pts))
+	pts[0] = poly.pts[0]
+	for h := 1; h < len(poly.pts); h++ {
+		pts[h] = poly.pts[len(poly.pts)-h]
+	}
+	return PolygonPt(pts...)
+}
 func (poly Polygon) Rotate(theta Radians, origin Pt) Polygon {
 	return PolygonPt(RotatePts(theta, origin, poly.pts[:])...)
 }
@@ -265,6 +591,26 @@ func (poly Polygon) Sides() []Segment {
 	sides = append(sides, SegmentPt(prev, poly.pts[0]))
 	return sides
 }
+
+// SignedArea returns the area of the polygon using the shoelace formula. The
+// area is positive for counter-clockwise polygons and negative for clockwise
+// polygons.
+func (poly Polygon) SignedArea() Length {
+	// see https://en.wikipedia.org/wiki/Shoelace_formula
+	if len(poly.pts) < 3 {
+		return 0
+	}
+
+	// Work relative to the first point to limit the size of the products.
+	origin := poly.pts[0]
+	var sum Length
+	for h := 1; h < len(poly.pts)-1; h++ {
+		ax, ay := origin.VectorTo(poly.pts[h]).Units()
+		bx, by := origin.VectorTo(poly.pts[h+1]).Units()
+		sum += ax*by - bx*ay
+	}
+	return sum / 2
+}
 func (poly Polygon) String() string {
 	var slice []string
 	for _, p := range poly.pts {
@@ -276,3 +622,37 @@ func (poly Polygon) String() string {
 func (poly Polygon) Translate(direction Vector) Polygon {
 	return PolygonPt(TranslatePts(direction, poly.pts[:])...)
 }
+
+// Winding returns the direction the points of the polygon travel. Returns
+// \c WINDING_NONE if the polygon has no area.
+func (poly Polygon) Winding() Winding { return windingFromArea(poly.SignedArea()) }
+
+// WindingNumber returns the number of times the polygon travels around \c p.
+// Counter-clockwise loops are positive, clockwise loops are negative. The
+// result is undefined for points on the boundary of the polygon.
+func (poly Polygon) WindingNumber(p Pt) int {
+	// see https://en.wikipedia.org/wiki/Point_in_polygon#Winding_number_algorithm
+	var wn int
+	y := p.Y()
+	for _, side := range poly.Sides() {
+		b, e := side.Begin(), side.End()
+		if b.Y() <= y {
+			if e.Y() > y && crossPts(b, e, p) > 0 {
+				wn++
+			}
+		} else if e.Y() <= y && crossPts(b, e, p) < 0 {
+			wn--
+		}
+	}
+	return wn
+}
+
+func windingFromArea(area Length) Winding {
+	switch {
+	case IsZero(area):
+		return WINDING_NONE
+	case area < 0:
+		return WINDING_CLOCKWISE
+	}
+	return WINDING_COUNTERCLOCKWISE
+}
@@ -55,6 +55,68 @@ func TestRectangle(t *testing.T) {
 
 	}
 
+	areaTests := []struct {
+		a           Rectangle
+		area, sarea Length
+		centroid    Pt
+		winding     Winding
+	}{
+		{RectanglePt(PtXy(2, -2), PtXy(-2, 2)), 16, -16, PtOrig, WINDING_CLOCKWISE},
+		{RectanglePt(PtXy(1, 1), PtXy(4, 3)), 6, -6, PtXy(2.5, 2), WINDING_CLOCKWISE},
+		{RectanglePt(PtXy(1, 1), PtXy(1, 3)), 0, 0, PtXy(1, 2), WINDING_NONE},
+	}
+	for h, test := range areaTests {
+		a := test.a
+		if area := a.Area(); !IsEqual(area, test.area) {
+			t.Errorf("[%d](%s).Area() failed. %f != %f",
+				h, a, area, test.area)
+		}
+		if sarea := a.SignedArea(); !IsEqual(sarea, test.sarea) {
+			t.Errorf("[%d](%s).SignedArea() failed. %f != %f",
+				h, a, sarea, test.sarea)
+		}
+		if centroid := a.Centroid(); !IsEqualPair(centroid, test.centroid) {
+			t.Errorf("[%d](%s).Centroid() failed. %v != %v",
+				h, a, centroid, test.centroid)
+		}
+		if winding := a.Winding(); winding != test.winding {
+			t.Errorf("[%d](%s).Winding() failed. %d != %d",
+				h, a, winding, test.winding)
+		}
+		if winding := a.Normalize().Winding(); !IsZero(test.area) && winding != WINDING_COUNTERCLOCKWISE {
+			t.Errorf("[%d](%s).Normalize() failed. %d != %d",
+				h, a, winding, WINDING_COUNTERCLOCKWISE)
+		}
+	}
+
+	containsTests := []struct {
+		a Rectangle
+		p Pt
+		c Containment
+	}{
+		{RectanglePt(PtXy(2, -2), PtXy(-2, 2)), PtOrig, CONTAINMENT_INSIDE},
+		{RectanglePt(PtXy(2, -2), PtXy(-2, 2)), PtXy(2, 0), CONTAINMENT_BOUNDARY},
+		{RectanglePt(PtXy(2, -2), PtXy(-2, 2)), PtXy(-2, -2), CONTAINMENT_BOUNDARY},
+		{RectanglePt(PtXy(2, -2), PtXy(-2, 2)), PtXy(1, 2.000000001), CONTAINMENT_BOUNDARY},
+		{RectanglePt(PtXy(2, -2), PtXy(-2, 2)), PtXy(1, 1.999999999), CONTAINMENT_BOUNDARY},
+		{RectanglePt(PtXy(2, -2), PtXy(-2, 2)), PtXy(-1.999999999, 0), CONTAINMENT_BOUNDARY},
+		{RectanglePt(PtXy(2, -2), PtXy(-2, 2)), PtXy(3, 0), CONTAINMENT_OUTSIDE},
+		{RectanglePt(PtXy(2, -2), PtXy(-2, 2)), PtXy(2, 3), CONTAINMENT_OUTSIDE},
+		{RectanglePt(PtXy(2, -2), PtXy(-2, 2)), PtNaN, CONTAINMENT_OUTSIDE},
+	}
+	for h, test := range containsTests {
+		a := test.a
+		if c := a.ContainsPt(test.p); c != test.c {
+			t.Errorf("[%d](%s).ContainsPt(%v) failed. %d != %d",
+				h, a, test.p, c, test.c)
+		}
+		// A rectangle agrees with the same polygon.
+		if c := PolygonFromRectangle(a).ContainsPt(test.p, FILL_RULE_NON_ZERO); c != test.c {
+			t.Errorf("[%d]PolygonFromRectangle(%s).ContainsPt(%v) failed. %d != %d",
+				h, a, test.p, c, test.c)
+		}
+	}
+
 	errorTests := []struct {
 		a     Rectangle
 		isErr bool
@@ -118,6 +180,204 @@ func TestPolygon(t *testing.T) {
 		}
 	}
 
+	areaTests := []struct {
+		a           Polygon
+		area, sarea Length
+		centroid    Pt
+		winding     Winding
+	}{
+		{
+			//0
+			Square,
+			1, 1, PtXy(0.5, 0.5), WINDING_COUNTERCLOCKWISE,
+		}, {
+			PolygonPt(PtXy(0, 1), PtXy(1, 1), PtXy(1, 0), PtXy(0, 0)),
+			1, -1, PtXy(0.5, 0.5), WINDING_CLOCKWISE,
+		}, {
+			TriangleIsoscelesRight,
+			0.25, 0.25, PtXy(0.4714045207910, 0.2357022603955), WINDING_COUNTERCLOCKWISE,
+		}, {
+			// L shape
+			PolygonPt(PtOrig, PtXy(20, 0), PtXy(20, 10), PtXy(10, 10), PtXy(10, 30), PtXy(0, 30)),
+			400, 400, PtXy(7.5, 12.5), WINDING_COUNTERCLOCKWISE,
+		}, {
+			PolygonPt(PtXy(1000, 1000), PtXy(1000, 1002), PtXy(1003, 1002), PtXy(1003, 1000)),
+			6, -6, PtXy(1001.5, 1001), WINDING_CLOCKWISE,
+		}, {
+			//5
+			PolygonPt(PtOrig, PtXy(1, 1), PtXy(2, 2)),
+			0, 0, PtNaN, WINDING_NONE,
+		}, {
+			PolygonPt(PtOrig, PtXy(1, 1)),
+			0, 0, PtNaN, WINDING_NONE,
+		},
+	}
+	for h, test := range areaTests {
+		a := test.a
+		if area := a.Area(); !IsEqual(area, test.area) {
+			t.Errorf("[%d](%s).Area() failed. %f != %f",
+				h, a, area, test.area)
+		}
+		if sarea := a.SignedArea(); !IsEqual(sarea, test.sarea) {
+			t.Errorf("[%d](%s).SignedArea() failed. %f != %f",
+				h, a, sarea, test.sarea)
+		}
+		tc, terr := test.centroid.OrErr()
+		if c, err := a.Centroid().OrErr(); (err == nil) != (terr == nil) {
+			t.Errorf("[%d](%s).Centroid() failed (error). %v != %v",
+				h, a, err, terr)
+		} else if terr == nil && !IsEqualPair(c, tc) {
+			t.Errorf("[%d](%s).Centroid() failed. %v != %v",
+				h, a, c, tc)
+		}
+		if winding := a.Winding(); winding != test.winding {
+			t.Errorf("[%d](%s).Winding() failed. %d != %d",
+				h, a, winding, test.winding)
+		}
+
+		normal := a.Normalize()
+		expected := test.winding
+		if expected == WINDING_CLOCKWISE {
+			expected = WINDING_COUNTERCLOCKWISE
+		}
+		if winding := normal.Winding(); winding != expected {
+			t.Errorf("[%d](%s).Normalize() failed. %d != %d",
+				h, a, winding, expected)
+		}
+		if area := normal.SignedArea(); !IsEqual(area, test.area) {
+			t.Errorf("[%d](%s).Normalize() failed (area). %f != %f",
+				h, a, area, test.area)
+		}
+	}
+
+	reverseTests := []struct {
+		a Polygon
+		r Polygon
+	}{
+		{Square, PolygonPt(PtOrig, PtXy(0, 1), PtXy(1, 1), PtXy(1, 0))},
+		{PolygonPt(), PolygonPt()},
+	}
+	for h, test := range reverseTests {
+		a := test.a
+		if r := a.Reverse(); !IsEqualPts(r, test.r) {
+			t.Errorf("[%d](%s).Reverse() failed. %v != %v",
+				h, a, r, test.r)
+		}
+	}
+
+	star := PolygonPt(PtXy(0, 0), PtXy(4, 10), PtXy(8, 0), PtXy(-2, 6), PtXy(10, 6))
+	containsTests := []struct {
+		a      Polygon
+		p      Pt
+		wn     int
+		eo, nz Containment
+	}{
+		{
+			//0
+			Square, PtXy(0.5, 0.5), 1,
+			CONTAINMENT_INSIDE, CONTAINMENT_INSIDE,
+		}, {
+			Square, PtXy(1.5, 0.5), 0,
+			CONTAINMENT_OUTSIDE, CONTAINMENT_OUTSIDE,
+		}, {
+			Square, PtXy(1, 0.5), 0,
+			CONTAINMENT_BOUNDARY, CONTAINMENT_BOUNDARY,
+		}, {
+			Square, PtXy(1, 1), 0,
+			CONTAINMENT_BOUNDARY, CONTAINMENT_BOUNDARY,
+		}, {
+			Square, PtXy(0.5, 1.000000001), 0,
+			CONTAINMENT_BOUNDARY, CONTAINMENT_BOUNDARY,
+		}, {
+			//5
+			// ray passes through a vertex
+			PolygonPt(PtXy(0, 0), PtXy(2, 1), PtXy(0, 2), PtXy(-2, 1)), PtXy(-3, 1), 0,
+			CONTAINMENT_OUTSIDE, CONTAINMENT_OUTSIDE,
+		}, {
+			PolygonPt(PtXy(0, 0), PtXy(2, 1), PtXy(0, 2), PtXy(-2, 1)), PtXy(0, 1), 1,
+			CONTAINMENT_INSIDE, CONTAINMENT_INSIDE,
+		}, {
+			Square.Reverse(), PtXy(0.5, 0.5), -1,
+			CONTAINMENT_INSIDE, CONTAINMENT_INSIDE,
+		}, {
+			// center of a pentagram
+			star, PtXy(4, 5), -2,
+			CONTAINMENT_OUTSIDE, CONTAINMENT_INSIDE,
+		}, {
+			// point of a pentagram
+			star, PtXy(4, 8), -1,
+			CONTAINMENT_INSIDE, CONTAINMENT_INSIDE,
+		}, {
+			//10
+			star, PtXy(4, 10), 0,
+			CONTAINMENT_BOUNDARY, CONTAINMENT_BOUNDARY,
+		}, {
+			PolygonPt(), PtOrig, 0,
+			CONTAINMENT_OUTSIDE, CONTAINMENT_OUTSIDE,
+		}, {
+			Square, PtNaN, 0,
+			CONTAINMENT_OUTSIDE, CONTAINMENT_OUTSIDE,
+		},
+	}
+	for h, test := range containsTests {
+		a := test.a
+		if c := a.ContainsPt(test.p, FILL_RULE_EVEN_ODD); c != test.eo {
+			t.Errorf("[%d](%s).ContainsPt(%v, FILL_RULE_EVEN_ODD) failed. %d != %d",
+				h, a, test.p, c, test.eo)
+		}
+		if c := a.ContainsPt(test.p, FILL_RULE_NON_ZERO); c != test.nz {
+			t.Errorf("[%d](%s).ContainsPt(%v, FILL_RULE_NON_ZERO) failed. %d != %d",
+				h, a, test.p, c, test.nz)
+		}
+		if test.eo != CONTAINMENT_BOUNDARY && len(a.Points()) > 0 {
+			if wn := a.WindingNumber(test.p); wn != test.wn {
+				t.Errorf("[%d](%s).WindingNumber(%v) failed. %d != %d",
+					h, a, test.p, wn, test.wn)
+			}
+		}
+	}
+
+	positiveTests := []struct {
+		a Polygon
+		p Pt
+		c Containment
+	}{
+		{Square, PtXy(0.5, 0.5), CONTAINMENT_INSIDE},
+		{Square.Reverse(), PtXy(0.5, 0.5), CONTAINMENT_OUTSIDE},
+		{Square.Reverse(), PtXy(1, 0.5), CONTAINMENT_BOUNDARY},
+		{star, PtXy(4, 5), CONTAINMENT_OUTSIDE},
+		{star.Reverse(), PtXy(4, 5), CONTAINMENT_INSIDE},
+	}
+	for h, test := range positiveTests {
+		a := test.a
+		if c := a.ContainsPt(test.p, FILL_RULE_POSITIVE); c != test.c {
+			t.Errorf("[%d](%s).ContainsPt(%v, FILL_RULE_POSITIVE) failed. %d != %d",
+				h, a, test.p, c, test.c)
+		}
+	}
+
+	convexTests := []struct {
+		a        Polygon
+		isConvex bool
+	}{
+		{Square, true},
+		{Square.Reverse(), true},
+		{TriangleEquilateral, true},
+		{PolygonPt(PtOrig, PtXy(1, 0), PtXy(2, 0), PtXy(2, 2), PtXy(0, 2)), true},
+		{PolygonPt(PtOrig, PtXy(2, 0), PtXy(2, 2), PtXy(0, 2), PtOrig), true},
+		{PolygonPt(PtOrig, PtXy(20, 0), PtXy(20, 10), PtXy(10, 10), PtXy(10, 30), PtXy(0, 30)), false},
+		{PolygonPt(PtXy(0, 0), PtXy(4, 10), PtXy(8, 0), PtXy(-2, 6), PtXy(10, 6)), false},
+		{PolygonPt(PtOrig, PtXy(1, 1), PtXy(2, 2)), false},
+		{PolygonPt(), false},
+	}
+	for h, test := range convexTests {
+		a := test.a
+		if isConvex := a.IsConvex(); isConvex != test.isConvex {
+			t.Errorf("[%d](%s).IsConvex() failed. %t != %t",
+				h, a, isConvex, test.isConvex)
+		}
+	}
+
 	errorTests := []struct {
 		a     Polygon
 		isErr bool
@@ -137,4 +397,188 @@ func TestPolygon(t *testing.T) {
 				h, test.a, (err != nil), test.isErr, err)
 		}
 	}
+
+	square := PolygonPt(PtXy(0, 0), PtXy(4, 0), PtXy(4, 4), PtXy(0, 4))
+	closestTests := []struct {
+		a    Polygon
+		p    Pt
+		t    float64
+		pt   Pt
+		dist Length
+	}{
+		{
+			//0
+			square, PtXy(5, 2),
+			1.5, PtXy(4, 2), 1,
+		}, {
+			square, PtXy(1, 2),
+			3.5, PtXy(0, 2), 1,
+		}, {
+			square, PtXy(-3, -4),
+			0, PtXy(0, 0), 5,
+		},
+	}
+	for h, test := range closestTests {
+		tv, pt, dist := test.a.ClosestPt(test.p)
+		if !IsEqual(tv, test.t) || !IsEqualPair(pt, test.pt) || !IsEqual(dist, test.dist) {
+			t.Errorf("[%d](%v).ClosestPt(%v) failed. %f, %v, %f != %f, %v, %f",
+				h, test.a, test.p, tv, pt, dist, test.t, test.pt, test.dist)
+		}
+	}
+}
+
+func TestConvexHull(t *testing.T) {
+	hullTests := []struct {
+		pts   []Pt
+		hull  Polygon
+		isErr bool
+	}{
+		{
+			//0
+			[]Pt{PtXy(1, 1), PtXy(0, 0), PtXy(2, 0), PtXy(2, 2), PtXy(0, 2), PtXy(1, 0)},
+			PolygonPt(PtXy(0, 0), PtXy(2, 0), PtXy(2, 2), PtXy(0, 2)),
+			false,
+		}, {
+			[]Pt{PtXy(3, 1), PtXy(1, 5), PtXy(-2, 2), PtXy(1, 5), PtXy(0, -3), PtXy(0, 1)},
+			PolygonPt(PtXy(-2, 2), PtXy(0, -3), PtXy(3, 1), PtXy(1, 5)),
+			false,
+		}, {
+			[]Pt{PtXy(0, 0), PtXy(3, 3), PtXy(1, 1), PtXy(2, 2)},
+			PolygonPt(PtXy(0, 0), PtXy(3, 3)),
+			false,
+		}, {
+			[]Pt{PtXy(5, 5), PtXy(5, 5), PtXy(5, 5)},
+			PolygonPt(PtXy(5, 5)),
+			false,
+		}, {
+			[]Pt{},
+			PolygonPt(),
+			false,
+		}, {
+			//5
+			[]Pt{PtXy(0, 0), PtXy(Length(math.NaN()), 1), PtXy(2, 2)},
+			PolygonPt(PtXy(Length(math.NaN()), 1)),
+			true,
+		}, {
+			[]Pt{PtXy(0, 0), PtXy(1, Length(math.Inf(1))), PtXy(2, 2)},
+			PolygonPt(PtXy(1, Length(math.Inf(1)))),
+			true,
+		},
+	}
+	for h, test := range hullTests {
+		hull := ConvexHullPts(test.pts...)
+		if _, err := hull.OrErr(); (err != nil) != test.isErr {
+			t.Errorf("[%d]ConvexHullPts(%v) failed (error). %t != %t. %v",
+				h, test.pts, (err != nil), test.isErr, err)
+			continue
+		} else if err != nil {
+			continue
+		}
+		if !IsEqualPts(hull, test.hull) {
+			t.Errorf("[%d]ConvexHullPts(%v) failed. %v != %v",
+				h, test.pts, hull, test.hull)
+		}
+	}
+
+	shapeTests := []struct {
+		shapes []OrderedPtser
+		hull   Polygon
+	}{
+		{
+			[]OrderedPtser{
+				Square,
+				BezierPt(PtXy(2, 0), PtXy(3, -1), PtXy(4, 1), PtXy(2, 1)),
+				SegmentPt(PtXy(0, 1), PtXy(-1, 3)),
+			},
+			PolygonPt(PtXy(-1, 3), PtXy(0, 0), PtXy(3, -1), PtXy(4, 1)),
+		}, {
+			[]OrderedPtser{CirclePt(PtXy(2, 2), 1).CircumscribedPolygon(4)},
+			PolygonPt(PtXy(0.585786437627, 2), PtXy(2, 0.585786437627), PtXy(3.414213562373, 2), PtXy(2, 3.414213562373)),
+		},
+	}
+	for h, test := range shapeTests {
+		hull := ConvexHull(test.shapes...)
+		if !IsEqualPts(hull, test.hull) {
+			t.Errorf("[%d]ConvexHull(%v) failed. %v != %v",
+				h, test.shapes, hull, test.hull)
+		}
+		if winding := hull.Winding(); winding != WINDING_COUNTERCLOCKWISE {
+			t.Errorf("[%d]ConvexHull(%v) failed (winding). %d != %d",
+				h, test.shapes, winding, WINDING_COUNTERCLOCKWISE)
+		}
+		if !hull.IsConvex() {
+			t.Errorf("[%d]ConvexHull(%v) failed (convex). %v",
+				h, test.shapes, hull)
+		}
+	}
+
+	// Circles are accepted directly, within the tolerance.
+	toleranceTests := []struct {
+		shapes    []HullPtser
+		tolerance Length
+		area      Length
+	}{
+		{
+			[]HullPtser{CirclePt(PtXy(0, 0), 1), CirclePt(PtXy(4, 0), 1)},
+			1e-3,
+			// Two half circles and the rectangle between them.
+			Length(math.Pi) + 8,
+		}, {
+			[]HullPtser{CirclePt(PtXy(0, 0), 1), Square, SegmentPt(PtXy(-3, 0), PtXy(0, 3))},
+			1e-4,
+			0,
+		}, {
+			[]HullPtser{CirclePt(PtXy(2, 2), 1), BezierPt(PtXy(2, 0), PtXy(3, -1), PtXy(4, 1), PtXy(2, 1))},
+			1e-3,
+			0,
+		},
+	}
+	for h, test := range toleranceTests {
+		hull := ConvexHullTolerance(test.tolerance, test.shapes...)
+		if winding := hull.Winding(); winding != WINDING_COUNTERCLOCKWISE || !hull.IsConvex() {
+			t.Errorf("[%d]ConvexHullTolerance(%f, %v) failed (convex). %v",
+				h, test.tolerance, test.shapes, hull)
+		}
+		if test.area > 0 && !(hull.Area() >= test.area && hull.Area()-test.area <= test.tolerance*hull.Perimeter()) {
+			t.Errorf("[%d]ConvexHullTolerance(%f, %v) (area) failed. %f != %f",
+				h, test.tolerance, test.shapes, hull.Area(), test.area)
+		}
+		// Every shape is inside, and the corners are no more than the
+		// tolerance outside.
+		for i, shape := range test.shapes {
+			if c, ok := shape.(Circle); ok {
+				for j := 0; j < 64; j++ {
+					p := c.PtAtT(float64(j) / 64)
+					if hull.ContainsPt(p, FILL_RULE_NON_ZERO) == CONTAINMENT_OUTSIDE {
+						t.Errorf("[%d][%d]ConvexHullTolerance(%f, %v) (inside) failed. %v",
+							h, i, test.tolerance, test.shapes, p)
+					}
+				}
+			}
+		}
+		for i, p := range hull.Points() {
+			var near bool
+			for _, shape := range test.shapes {
+				if c, ok := shape.(Circle); ok {
+					near = near || c.Center().VectorTo(p).Magnitude() <= c.Radius()+test.tolerance
+				} else {
+					for _, q := range shape.HullPts(test.tolerance) {
+						near = near || IsEqualPair(p, q)
+					}
+				}
+			}
+			if !near {
+				t.Errorf("[%d][%d]ConvexHullTolerance(%f, %v) (corner) failed. %v",
+					h, i, test.tolerance, test.shapes, p)
+			}
+		}
+	}
+
+	// Errors are reported by the hull.
+	if _, err := ConvexHullTolerance(0, CirclePt(PtXy(0, 0), 1)).OrErr(); err == nil {
+		t.Errorf("ConvexHullTolerance(0) failed. nil error")
+	}
+	if pts := CirclePt(PtXy(2, 3), 0).HullPts(1); len(pts) != 1 || !IsEqualPair(pts[0], PtXy(2, 3)) {
+		t.Errorf("(%v).HullPts(1) failed. %v", CirclePt(PtXy(2, 3), 0), pts)
+	}
 }
@@ -0,0 +1,384 @@
+package figuring
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// polynomialGCDTolerance is the size of a remainder coefficient, relative to
+// the terms that cancelled to produce it, that is treated as zero while
+// finding the greatest common divisor.
+const polynomialGCDTolerance = 1e-9
+
+// PolynomialN is a polynomial of any degree, in the form of
+// f(t) = a_n t^n + ... + a_1 t + a_0. Unlike \c Constant through \c Quartic,
+// it supports arithmetic between polynomials, so the equations that appear in
+// curve projection and intersection problems can be built directly.
+//
+// Coefficients are ordered from the highest degree to the constant, like
+// \c Coefficienter. Leading zero coefficients are removed, so the zero
+// polynomial has a single zero coefficient and a degree of 0.
+type PolynomialN struct {
+	coeffs []float64
+}
+
+// PolynomialNCoefficients creates a polynomial from its coefficients, ordered
+// from the highest degree to the constant.
+func PolynomialNCoefficients(coeffs ...float64) PolynomialN {
+	return polynomialN(append([]float64{}, coeffs...))
+}
+
+// PolynomialNFrom creates a polynomial with the same coefficients as \c c,
+// such as a \c Cubic or a \c Quartic.
+func PolynomialNFrom(c Coefficienter) PolynomialN {
+	return PolynomialNCoefficients(c.Coefficients()...)
+}
+
+// polynomialN creates a polynomial that owns \c coeffs, removing the leading
+// zeros.
+func polynomialN(coeffs []float64) PolynomialN {
+	for len(coeffs) > 1 && coeffs[0] == 0 {
+		coeffs = coeffs[1:]
+	}
+	if len(coeffs) == 0 {
+		coeffs = []float64{0}
+	}
+	return PolynomialN{coeffs: coeffs}
+}
+
+// Add returns the sum of the two polynomials.
+func (p PolynomialN) Add(q PolynomialN) PolynomialN {
+	return polynomialN(polynomialAdd(p.terms(), q.terms()))
+}
+
+// AtT evaluates the polynomial for the provided t value.
+func (p PolynomialN) AtT(t float64) float64 {
+	// see https://en.wikipedia.org/wiki/Horner%27s_method
+	var sum float64
+	for _, c := range p.terms() {
+		sum = math.FMA(sum, t, c)
+	}
+	return sum
+}
+
+// Coefficients implements the Coefficienter interface. The coefficients are
+// ordered from the highest degree to the constant. The slice is a copy.
+func (p PolynomialN) Coefficients() []float64 { return append([]float64{}, p.terms()...) }
+
+// Compose returns the polynomial p(q(t)).
+func (p PolynomialN) Compose(q PolynomialN) PolynomialN {
+	result := []float64{p.terms()[0]}
+	for _, c := range p.terms()[1:] {
+		result = polynomialAdd(polynomialMul(result, q.terms()), []float64{c})
+	}
+	return polynomialN(result)
+}
+
+// Constant converts the polynomial into a \c Constant. Returns the zero value
+// and false if the degree is too high.
+func (p PolynomialN) Constant() (Constant, bool) {
+	c, ok := p.padded(0)
+	if !ok {
+		return Constant{}, false
+	}
+	return ConstantA(c[0]), true
+}
+
+// Antiderivative returns the antiderivative of the polynomial, with a
+// constant of zero.
+func (p PolynomialN) Antiderivative() PolynomialN {
+	n := p.Degree()
+	antideriv := make([]float64, n+2)
+	for h, c := range p.terms() {
+		antideriv[h] = c / float64(n-h+1)
+	}
+	return polynomialN(antideriv)
+}
+
+// Cubic converts the polynomial into a \c Cubic. Returns the zero value
+// and false if the degree is too high.
+func (p PolynomialN) Cubic() (Cubic, bool) {
+	c, ok := p.padded(3)
+	if !ok {
+		return Cubic{}, false
+	}
+	return CubicAbcd(c[0], c[1], c[2], c[3]), true
+}
+
+// Degree is the polynomial degree. Also known as the largest exponent.
+func (p PolynomialN) Degree() int { return len(p.terms()) - 1 }
+
+// Derivative implements the Derivable interface. See \c FirstDerivative.
+func (p PolynomialN) Derivative() Polynomial { return p.FirstDerivative() }
+
+// Divide returns the quotient and the remainder of dividing the polynomial by
+// \c divisor, using polynomial long division. The degree of the remainder is
+// less than the degree of the divisor. Dividing by the zero polynomial
+// results in polynomials in error.
+func (p PolynomialN) Divide(divisor PolynomialN) (PolynomialN, PolynomialN) {
+	// see https://en.wikipedia.org/wiki/Polynomial_long_division
+	if divisor.IsZero() {
+		nan := PolynomialNCoefficients(math.NaN())
+		return nan, nan
+	}
+	n, m := p.Degree(), divisor.Degree()
+	if n < m {
+		return PolynomialNCoefficients(0), p
+	}
+	rem := append([]float64{}, p.terms()...)
+	quot := make([]float64, n-m+1)
+	lead := divisor.terms()[0]
+	for h := range quot {
+		c := rem[h] / lead
+		quot[h] = c
+		for i, d := range divisor.terms() {
+			rem[h+i] -= c * d
+		}
+		// The leading term is removed exactly, not just rounded to nearly
+		// zero.
+		rem[h] = 0
+	}
+	return polynomialN(quot), polynomialN(rem[n-m+1:])
+}
+
+// FirstDerivative returns the derivative of the polynomial.
+func (p PolynomialN) FirstDerivative() PolynomialN {
+	n := p.Degree()
+	if n == 0 {
+		return PolynomialNCoefficients(0)
+	}
+	deriv := make([]float64, n)
+	for h := range deriv {
+		deriv[h] = p.terms()[h] * float64(n-h)
+	}
+	return polynomialN(deriv)
+}
+
+// GCD returns the monic greatest common divisor of the two polynomials, using
+// the Euclidean algorithm. Remainders that are nearly zero, compared to the
+// terms that cancelled to produce them, are treated as zero, so polynomials
+// with rounded coefficients still share their common factors. Nearby roots
+// can be merged into a common factor. The greatest common divisor of two zero
+// polynomials is zero.
+func (p PolynomialN) GCD(q PolynomialN) PolynomialN {
+	// see https://en.wikipedia.org/wiki/Polynomial_greatest_common_divisor#Euclidean_algorithm
+	a, b := p, q
+	if a.Degree() < b.Degree() {
+		a, b = b, a
+	}
+	for !b.IsZero() {
+		r := a.remainder(b, polynomialGCDTolerance)
+		a, b = b.Monic(), r
+	}
+	return a.Monic()
+}
+
+// Integral implements the Integrable interface. See \c Antiderivative.
+func (p PolynomialN) Integral() Polynomial { return p.Antiderivative() }
+
+// IsZero returns true if every coefficient is zero.
+func (p PolynomialN) IsZero() bool {
+	for _, c := range p.coeffs {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Linear converts the polynomial into a \c Linear. Returns the zero value
+// and false if the degree is too high.
+func (p PolynomialN) Linear() (Linear, bool) {
+	c, ok := p.padded(1)
+	if !ok {
+		return Linear{}, false
+	}
+	return LinearAb(c[0], c[1]), true
+}
+
+// Monic returns the polynomial scaled so that the coefficient of the highest
+// degree is 1. The zero polynomial is returned unchanged.
+func (p PolynomialN) Monic() PolynomialN {
+	if p.IsZero() {
+		return p
+	}
+	return p.Scale(1 / p.terms()[0])
+}
+
+// Mul returns the product of the two polynomials.
+func (p PolynomialN) Mul(q PolynomialN) PolynomialN {
+	return polynomialN(polynomialMul(p.terms(), q.terms()))
+}
+
+// OrErr returns a floating point error if any coefficient is in error.
+func (p PolynomialN) OrErr() (PolynomialN, *FloatingPointError) {
+	for _, c := range p.terms() {
+		if math.IsNaN(c) || math.IsInf(c, 0) {
+			return p, &FloatingPointError{v: c}
+		}
+	}
+	return p, nil
+}
+
+// Quadratic converts the polynomial into a \c Quadratic. Returns the zero
+// value and false if the degree is too high.
+func (p PolynomialN) Quadratic() (Quadratic, bool) {
+	c, ok := p.padded(2)
+	if !ok {
+		return Quadratic{}, false
+	}
+	return QuadraticAbc(c[0], c[1], c[2]), true
+}
+
+// Quartic converts the polynomial into a \c Quartic. Returns the zero value
+// and false if the degree is too high.
+func (p PolynomialN) Quartic() (Quartic, bool) {
+	c, ok := p.padded(4)
+	if !ok {
+		return Quartic{}, false
+	}
+	return QuarticAbcde(c[0], c[1], c[2], c[3], c[4]), true
+}
+
+// Roots returns the real roots of the polynomial, sorted from least to
+// greatest. Repeated roots are only returned once. See \c RealRoots.
+func (p PolynomialN) Roots() []float64 {
+	n := p.Degree()
+	if n < 1 {
+		return nil
+	}
+	// Every root is inside the Cauchy bound.
+	// see https://en.wikipedia.org/wiki/Geometrical_properties_of_polynomial_roots#Lagrange's_and_Cauchy's_bounds
+	var bound float64
+	for _, c := range p.terms()[1:] {
+		bound = math.Max(bound, math.Abs(c/p.terms()[0]))
+	}
+	var roots []float64
+	for _, root := range p.RealRoots(-1-bound, 1+bound) {
+		roots = append(roots, root.T)
+	}
+	return roots
+}
+
+// Scale returns the polynomial with every coefficient multiplied by \c s.
+func (p PolynomialN) Scale(s float64) PolynomialN {
+	return polynomialN(polynomialMul(p.terms(), []float64{s}))
+}
+
+// String returns the formula of the polynomial.
+func (p PolynomialN) String() string { return p.Text('t', true) }
+
+// Sub returns the difference of the two polynomials.
+func (p PolynomialN) Sub(q PolynomialN) PolynomialN {
+	return p.Add(q.Scale(-1))
+}
+
+// Text returns a string representing the polynomial. See \c Polynomial.
+func (p PolynomialN) Text(unknown rune, addPrefix bool) string {
+	var sb strings.Builder
+	if addPrefix {
+		fmt.Fprintf(&sb, "f(%c)=", unknown)
+	}
+	n := p.Degree()
+	for h, c := range p.terms() {
+		if h > 0 {
+			op := '+'
+			if c < 0 {
+				op = '-'
+				c = -c
+			}
+			sb.WriteRune(op)
+		}
+		sb.WriteString(HumanFormat(9, c))
+		switch power := n - h; power {
+		case 0:
+			if n == 0 {
+				fmt.Fprintf(&sb, "(%c^0)", unknown)
+			}
+		case 1:
+			sb.WriteRune(unknown)
+		default:
+			fmt.Fprintf(&sb, "%c^%d", unknown, power)
+		}
+	}
+	return sb.String()
+}
+
+// padded returns the coefficients with leading zeros added up to \c degree.
+// Returns nil and false if the polynomial has a higher degree.
+func (p PolynomialN) padded(degree int) ([]float64, bool) {
+	if p.Degree() > degree {
+		return nil, false
+	}
+	c := make([]float64, degree+1)
+	copy(c[degree-p.Degree():], p.terms())
+	return c, true
+}
+
+// remainder returns the remainder of dividing the polynomial by \c divisor,
+// which must not be zero. Coefficients are set to zero when they are no
+// larger than \c tolerance times the terms that cancelled to produce them,
+// because those coefficients are left over from rounding.
+func (p PolynomialN) remainder(divisor PolynomialN, tolerance float64) PolynomialN {
+	n, m := p.Degree(), divisor.Degree()
+	if n < m {
+		return p
+	}
+	rem := append([]float64{}, p.terms()...)
+	size := make([]float64, len(rem))
+	for h, c := range rem {
+		size[h] = math.Abs(c)
+	}
+	// The size of each quotient term carries the rounding of the remainder
+	// it came from into the later coefficients.
+	lead := divisor.terms()[0]
+	for h := 0; h <= n-m; h++ {
+		c, csize := rem[h]/lead, size[h]/math.Abs(lead)
+		for i, d := range divisor.terms() {
+			rem[h+i] -= c * d
+			size[h+i] += csize * math.Abs(d)
+		}
+	}
+	return polynomialRounded(rem[n-m+1:], size[n-m+1:], tolerance)
+}
+
+// subRounded returns the difference of the two polynomials. Coefficients are
+// set to zero when they are no larger than \c tolerance times the terms that
+// cancelled to produce them. See \c remainder.
+func (p PolynomialN) subRounded(q PolynomialN, tolerance float64) PolynomialN {
+	n := len(p.terms())
+	if len(q.terms()) > n {
+		n = len(q.terms())
+	}
+	diff, size := make([]float64, n), make([]float64, n)
+	for h, c := range p.terms() {
+		diff[n-len(p.terms())+h] += c
+		size[n-len(p.terms())+h] += math.Abs(c)
+	}
+	for h, c := range q.terms() {
+		diff[n-len(q.terms())+h] -= c
+		size[n-len(q.terms())+h] += math.Abs(c)
+	}
+	return polynomialRounded(diff, size, tolerance)
+}
+
+// terms returns the coefficients, treating the zero value as the zero
+// polynomial.
+func (p PolynomialN) terms() []float64 {
+	if len(p.coeffs) == 0 {
+		return []float64{0}
+	}
+	return p.coeffs
+}
+
+// polynomialRounded creates a polynomial from \c coeffs, setting every
+// coefficient that is no larger than \c tolerance times its \c size to zero.
+func polynomialRounded(coeffs, size []float64, tolerance float64) PolynomialN {
+	for h, c := range coeffs {
+		if math.Abs(c) <= tolerance*size[h] {
+			coeffs[h] = 0
+		}
+	}
+	return polynomialN(coeffs)
+}
@@ -0,0 +1,241 @@
+package figuring
+
+import (
+	"testing"
+)
+
+func TestPolynomialN(t *testing.T) {
+	pn := PolynomialNCoefficients
+	identityTests := []struct {
+		eq         PolynomialN
+		s          string
+		degree     int
+		cofs       []float64
+		roots      []float64
+		derivative PolynomialN
+	}{
+		{
+			//0
+			pn(1, 0, -2, 3), "f(t)=1t^3+0t^2-2t+3", 3, []float64{1, 0, -2, 3},
+			[]float64{-1.8932891963551}, pn(3, 0, -2),
+		}, {
+			pn(0, 0, 2, -4), "f(t)=2t-4", 1, []float64{2, -4},
+			[]float64{2}, pn(2),
+		}, {
+			pn(), "f(t)=0(t^0)", 0, []float64{0},
+			nil, pn(0),
+		}, {
+			PolynomialN{}, "f(t)=0(t^0)", 0, []float64{0},
+			nil, pn(0),
+		}, {
+			// (t-1)(t-2)(t-3)(t+1)(t+2)
+			pn(1, -3, -5, 15, 4, -12), "f(t)=1t^5-3t^4-5t^3+15t^2+4t-12", 5, []float64{1, -3, -5, 15, 4, -12},
+			[]float64{-2, -1, 1, 2, 3}, pn(5, -12, -15, 30, 4),
+		}, {
+			//5
+			// (t-1)^2(t+2)
+			pn(1, 0, -3, 2), "f(t)=1t^3+0t^2-3t+2", 3, []float64{1, 0, -3, 2},
+			[]float64{-2, 1}, pn(3, 0, -3),
+		}, {
+			pn(1, 0, 1), "f(t)=1t^2+0t+1", 2, []float64{1, 0, 1},
+			nil, pn(2, 0),
+		},
+	}
+	for h, test := range identityTests {
+		eq := test.eq
+		if s := eq.String(); s != test.s {
+			t.Errorf("[%d](%v).String() failed. %s != %s",
+				h, eq, s, test.s)
+		}
+		if degree := eq.Degree(); degree != test.degree {
+			t.Errorf("[%d](%v).Degree() failed. %d != %d",
+				h, eq, degree, test.degree)
+		}
+		if cofs := eq.Coefficients(); !IsEqualEquations(eq, PolynomialNCoefficients(test.cofs...)) || len(cofs) != len(test.cofs) {
+			t.Errorf("[%d](%v).Coefficients() failed. %v != %v",
+				h, eq, cofs, test.cofs)
+		}
+		roots := eq.Roots()
+		if len(roots) != len(test.roots) {
+			t.Errorf("[%d](%v).Roots() length failed. %v != %v",
+				h, eq, roots, test.roots)
+			continue
+		}
+		for i := range roots {
+			if !IsEqual(roots[i], test.roots[i]) {
+				t.Errorf("[%d][%d](%v).Roots() failed. %f != %f",
+					h, i, eq, roots[i], test.roots[i])
+			}
+		}
+		var poly Polynomial = eq
+		if _, ok := poly.(Derivable); !ok {
+			t.Errorf("[%d](%v).Derivative() failed. couldn't be converted for %T",
+				h, eq, eq)
+		} else if deq := eq.FirstDerivative(); !IsEqualEquations(deq, test.derivative) {
+			t.Errorf("[%d](%v).FirstDerivative() failed. %v != %v",
+				h, eq, deq, test.derivative)
+		}
+	}
+
+	// The coefficients are a copy.
+	eq := pn(1, 2, 3)
+	eq.Coefficients()[0] = 5
+	if c := eq.Coefficients()[0]; c != 1 {
+		t.Errorf("(%v).Coefficients() (copy) failed. %f != 1", eq, c)
+	}
+
+	atTests := []struct {
+		eq      PolynomialN
+		b, m, e float64
+	}{
+		{pn(-1, 3, 13, 2), 1172, 9.583823, -568},
+		{pn(1, -3, -5, 15, 4, -12), -123552, -6.605780, 66528},
+		{pn(7), 7, 7, 7},
+	}
+	for h, test := range atTests {
+		eq := test.eq
+		if b := eq.AtT(-10); !IsEqual(b, test.b) {
+			t.Errorf("[%d](%v).AtT(-10) failed. %f != %f", h, eq, b, test.b)
+		}
+		if m := eq.AtT(0.53); !IsEqual(m, test.m) {
+			t.Errorf("[%d](%v).AtT(0.53) failed. %f != %f", h, eq, m, test.m)
+		}
+		if e := eq.AtT(10); !IsEqual(e, test.e) {
+			t.Errorf("[%d](%v).AtT(10) failed. %f != %f", h, eq, e, test.e)
+		}
+	}
+
+	arithmeticTests := []struct {
+		name     string
+		got      PolynomialN
+		expected PolynomialN
+	}{
+		{"Add", pn(1, 2, 3).Add(pn(4, 5)), pn(1, 6, 8)},
+		{"Add", pn(1, 2, 3).Add(pn(-1, 0, 0)), pn(2, 3)},
+		{"Sub", pn(1, 2, 3).Sub(pn(4, 5)), pn(1, -2, -2)},
+		{"Sub", pn(1, 2, 3).Sub(pn(1, 2, 3)), pn(0)},
+		{"Mul", pn(1, -1).Mul(pn(1, 1)), pn(1, 0, -1)},
+		{"Mul", pn(1, -1).Mul(pn(1, -2)).Mul(pn(1, -3)).Mul(pn(1, 1)).Mul(pn(1, 2)), pn(1, -3, -5, 15, 4, -12)},
+		{"Mul", pn(1, -1).Mul(pn(0)), pn(0)},
+		{"Scale", pn(1, -2, 3).Scale(-2), pn(-2, 4, -6)},
+		{"Scale", pn(1, -2, 3).Scale(0), pn(0)},
+		{"Compose", pn(1, 0, 1).Compose(pn(1, -1)), pn(1, -2, 2)},
+		{"Compose", pn(2, 3).Compose(pn(1, 0, 0)), pn(2, 0, 3)},
+		{"Compose", pn(5).Compose(pn(1, 0, 0)), pn(5)},
+		{"Monic", pn(2, 4, -6).Monic(), pn(1, 2, -3)},
+	}
+	for h, test := range arithmeticTests {
+		if !IsEqualEquations(test.got, test.expected) {
+			t.Errorf("[%d]%s() failed. %v != %v", h, test.name, test.got, test.expected)
+		}
+	}
+
+	divideTests := []struct {
+		a, b      PolynomialN
+		quot, rem PolynomialN
+	}{
+		{pn(1, -2, 0, -4), pn(1, -3), pn(1, 1, 3), pn(5)},
+		{pn(1, 0, -1), pn(1, 1), pn(1, -1), pn(0)},
+		{pn(1, 1), pn(1, 0, 0), pn(0), pn(1, 1)},
+		{pn(6, 5, 1), pn(2), pn(3, 2.5, 0.5), pn(0)},
+		{pn(1, -3, -5, 15, 4, -12), pn(1, 0, -1), pn(1, -3, -4, 12), pn(0)},
+	}
+	for h, test := range divideTests {
+		quot, rem := test.a.Divide(test.b)
+		if !IsEqualEquations(quot, test.quot) || !IsEqualEquations(rem, test.rem) {
+			t.Errorf("[%d](%v).Divide(%v) failed. %v, %v != %v, %v",
+				h, test.a, test.b, quot, rem, test.quot, test.rem)
+		}
+		// a = b * quot + rem
+		if a := test.b.Mul(quot).Add(rem); !IsEqualEquations(a, test.a) {
+			t.Errorf("[%d](%v).Divide(%v) (product) failed. %v != %v",
+				h, test.a, test.b, a, test.a)
+		}
+	}
+	if quot, _ := pn(1, 2).Divide(pn(0)); true {
+		if _, err := quot.OrErr(); err == nil {
+			t.Errorf("(%v).Divide(%v) failed. %v should be in error", pn(1, 2), pn(0), quot)
+		}
+	}
+	if _, err := pn(1, 2).OrErr(); err != nil {
+		t.Errorf("(%v).OrErr() failed. %v", pn(1, 2), err)
+	}
+
+	gcdTests := []struct {
+		a, b PolynomialN
+		gcd  PolynomialN
+	}{
+		{
+			//0
+			pn(1, -2).Mul(pn(1, 3)).Mul(pn(1, -1)), pn(1, -2).Mul(pn(1, 3)).Mul(pn(2, 10)),
+			pn(1, 1, -6),
+		}, {
+			pn(1, -2).Mul(pn(1, 3)).Mul(pn(2, 10)), pn(1, -2).Mul(pn(1, 3)).Mul(pn(1, -1)),
+			pn(1, 1, -6),
+		}, {
+			pn(1, -1), pn(1, 1),
+			pn(1),
+		}, {
+			pn(3, -6), pn(0),
+			pn(1, -2),
+		}, {
+			pn(0), pn(0),
+			pn(0),
+		}, {
+			//5
+			// A polynomial and its derivative share the repeated root.
+			pn(1, 0, -3, 2), pn(3, 0, -3),
+			pn(1, -1),
+		}, {
+			// Rounded roots still share a factor.
+			pn(1, -1.0/3).Mul(pn(1, 0.1)), pn(1, -1.0/3).Mul(pn(1, 7)),
+			pn(1, -1.0/3),
+		}, {
+			// Small coefficients are not rounding.
+			pn(1, 0, 1e-12), pn(2, 0),
+			pn(1),
+		}, {
+			pn(1, 0, 0, 0, 0, -1e-20), pn(5, 0, 0, 0, 0),
+			pn(1),
+		},
+	}
+	for h, test := range gcdTests {
+		if gcd := test.a.GCD(test.b); !IsEqualEquations(gcd, test.gcd) {
+			t.Errorf("[%d](%v).GCD(%v) failed. %v != %v", h, test.a, test.b, gcd, test.gcd)
+		}
+	}
+
+	// Conversions to and from the fixed degree types.
+	cubic := CubicAbcd(-1, 3, 13, 2)
+	if c, ok := PolynomialNFrom(cubic).Cubic(); !ok || !IsEqualEquations(c, cubic) {
+		t.Errorf("PolynomialNFrom(%v).Cubic() failed. %v, %t", cubic, c, ok)
+	}
+	if q, ok := PolynomialNFrom(cubic).Quartic(); !ok || !IsEqualEquations(q, QuarticAbcde(0, -1, 3, 13, 2)) {
+		t.Errorf("PolynomialNFrom(%v).Quartic() failed. %v, %t", cubic, q, ok)
+	}
+	if q, ok := PolynomialNFrom(cubic).Quadratic(); ok || q != (Quadratic{}) {
+		t.Errorf("PolynomialNFrom(%v).Quadratic() failed. should not fit. %v", cubic, q)
+	}
+	if l, ok := pn(0, 0, 4, 5).Linear(); !ok || !IsEqualEquations(l, LinearAb(4, 5)) {
+		t.Errorf("(%v).Linear() failed. %v, %t", pn(0, 0, 4, 5), l, ok)
+	}
+	if c, ok := pn(7).Constant(); !ok || !IsEqualEquations(c, ConstantA(7)) {
+		t.Errorf("(%v).Constant() failed. %v, %t", pn(7), c, ok)
+	}
+	if q, ok := pn(1, 0, 0, 0, 0, 0).Quartic(); ok || q != (Quartic{}) {
+		t.Errorf("(%v).Quartic() failed. should not fit. %v", pn(1, 0, 0, 0, 0, 0), q)
+	}
+	if c, ok := pn(1, 2, 3, 4, 5, 6).Cubic(); ok || c != (Cubic{}) {
+		t.Errorf("(%v).Cubic() failed. should not fit. %v", pn(1, 2, 3, 4, 5, 6), c)
+	}
+	if l, ok := pn(1, 2, 3).Linear(); ok || l != (Linear{}) {
+		t.Errorf("(%v).Linear() failed. should not fit. %v", pn(1, 2, 3), l)
+	}
+	if c, ok := pn(1, 2).Constant(); ok || c != (Constant{}) {
+		t.Errorf("(%v).Constant() failed. should not fit. %v", pn(1, 2), c)
+	}
+	quartic := QuarticAbcde(1, -2, 3, -4, 5)
+	if q, ok := PolynomialNFrom(quartic).Quartic(); !ok || !IsEqualEquations(q, quartic) {
+		t.Errorf("PolynomialNFrom(%v).Quartic() failed. %v, %t", quartic, q, ok)
+	}
+}
@@ -138,6 +138,15 @@ func (x byXThenY) Less(i, j int) bool {
 }
 func (x byXThenY) Swap(i, j int) { x[i], x[j] = x[j], x[i] }
 
+// crossPts returns the cross product of the vectors \c a to \c b and \c a to
+// \c c. The result is positive when \c c is to the left of the vector \c a to
+// \c b, negative when it is to the right, and zero when the three points are
+// collinear.
+func crossPts(a, b, c Pt) Length {
+	ab, ac := a.VectorTo(b), a.VectorTo(c)
+	return Length(ab.ij[0]*ac.ij[1] - ab.ij[1]*ac.ij[0])
+}
+
 // Vector represents a direction and a magnitude.
 // See https://scholarsarchive.byu.edu/cgi/viewcontent.cgi?article=1000&context=facpub
 type Vector struct {
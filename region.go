@@ -0,0 +1,325 @@
+package figuring
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Region represents an area bounded by an outer polygon, with zero or more
+// polygon holes cut out of it. The outer polygon is kept counter-clockwise
+// and the holes are kept clockwise. Holes are expected to be inside the outer
+// polygon and to not overlap each other.
+type Region struct {
+	outer Polygon
+	holes []Polygon
+}
+
+// RegionPolygon creates a region from an outer polygon and the holes inside
+// of it. The winding of the provided polygons is ignored.
+func RegionPolygon(outer Polygon, holes ...Polygon) Region {
+	r := Region{
+		outer: outer.Normalize(),
+		holes: make([]Polygon, 0, len(holes)),
+	}
+	for _, hole := range holes {
+		if hole.Winding() != WINDING_NONE {
+			r.holes = append(r.holes, hole.Normalize().Reverse())
+		}
+	}
+	return r
+}
+
+// Area returns the area of the outer polygon, less the area of the holes.
+func (r Region) Area() Length {
+	sum := r.outer.Area()
+	for _, hole := range r.holes {
+		sum -= hole.Area()
+	}
+	return sum
+}
+
+// BoundingBox returns an axis-aligned rectangle that encompasses the outer
+// polygon.
+func (r Region) BoundingBox() Rectangle { return r.outer.BoundingBox() }
+
+// ContainsPt returns if \c p is inside, outside, or on the boundary of the
+// region. Points inside a hole are outside the region, and points on the
+// boundary of a hole are on the boundary of the region.
+func (r Region) ContainsPt(p Pt) Containment {
+	c := r.outer.ContainsPt(p, FILL_RULE_NON_ZERO)
+	if c != CONTAINMENT_INSIDE {
+		return c
+	}
+	for _, hole := range r.holes {
+		switch hole.ContainsPt(p, FILL_RULE_NON_ZERO) {
+		case CONTAINMENT_INSIDE:
+			return CONTAINMENT_OUTSIDE
+		case CONTAINMENT_BOUNDARY:
+			return CONTAINMENT_BOUNDARY
+		}
+	}
+	return CONTAINMENT_INSIDE
+}
+
+// Holes returns the clockwise polygons cut out of the region.
+func (r Region) Holes() []Polygon { return r.holes[:] }
+
+// OrErr returns a floating point error if any point of the region is in
+// error.
+func (r Region) OrErr() (Region, *FloatingPointError) {
+	var err *FloatingPointError
+	for _, ring := range r.Rings() {
+		_, perr := ring.OrErr()
+		if perr != nil && perr.IsNaN() {
+			return r, perr
+		} else if perr != nil {
+			err = perr
+		}
+	}
+	return r, err
+}
+
+// Outer returns the counter-clockwise polygon that bounds the region.
+func (r Region) Outer() Polygon { return r.outer }
+
+// Perimeter returns the combined perimeter of the outer polygon and the
+// holes.
+func (r Region) Perimeter() Length {
+	sum := r.outer.Perimeter()
+	for _, hole := range r.holes {
+		sum += hole.Perimeter()
+	}
+	return sum
+}
+
+// Points returns the points of the outer polygon followed by the points of
+// each hole.
+func (r Region) Points() []Pt {
+	pts := append([]Pt{}, r.outer.Points()...)
+	for _, hole := range r.holes {
+		pts = append(pts, hole.Points()...)
+	}
+	return pts
+}
+
+// Rings returns the outer polygon followed by the holes.
+func (r Region) Rings() []Polygon {
+	rings := make([]Polygon, 0, len(r.holes)+1)
+	rings = append(rings, r.outer)
+	return append(rings, r.holes...)
+}
+
+// Rotate rotates the region \c theta radians around \c origin.
+func (r Region) Rotate(theta Radians, origin Pt) Region {
+	return r.apply(func(poly Polygon) Polygon { return poly.Rotate(theta, origin) })
+}
+
+// Scale scales the coordinates of the region by \c scalars.
+func (r Region) Scale(scalars Vector) Region {
+	return r.apply(func(poly Polygon) Polygon { return poly.Scale(scalars) })
+}
+
+// String returns the polygons of the region.
+func (r Region) String() string {
+	var slice []string
+	for _, ring := range r.Rings() {
+		slice = append(slice, ring.String())
+	}
+	return fmt.Sprintf("Region(%s)", strings.Join(slice, ", "))
+}
+
+// Translate moves the region in the provided direction.
+func (r Region) Translate(direction Vector) Region {
+	return r.apply(func(poly Polygon) Polygon { return poly.Translate(direction) })
+}
+
+// apply transforms every polygon of the region. The windings are restored
+// afterwards, as transforms like mirroring reverse them.
+func (r Region) apply(f func(Polygon) Polygon) Region {
+	holes := make([]Polygon, len(r.holes))
+	for h, hole := range r.holes {
+		holes[h] = f(hole)
+	}
+	return RegionPolygon(f(r.outer), holes...)
+}
+
+// MultiRegion is a collection of regions treated as a single shape. The
+// regions are expected to not overlap each other.
+type MultiRegion struct {
+	regions []Region
+}
+
+// MultiRegionFromRegions creates a collection from the provided regions.
+func MultiRegionFromRegions(regions ...Region) MultiRegion {
+	return MultiRegion{
+		regions: regions,
+	}
+}
+
+// MultiRegionFromRings creates a collection from a set of rings, such as the
+// result of \c BooleanPolygonPolygon. Counter-clockwise rings become the outer
+// polygons of regions. Clockwise rings become holes in the smallest region
+// that contains them. Holes not contained by any region, and rings without
+// area, are dropped.
+func MultiRegionFromRings(rings ...Polygon) MultiRegion {
+	var outers, holes []Polygon
+	for _, ring := range rings {
+		switch ring.Winding() {
+		case WINDING_COUNTERCLOCKWISE:
+			outers = append(outers, ring)
+		case WINDING_CLOCKWISE:
+			holes = append(holes, ring)
+		}
+	}
+
+	owned := make([][]Polygon, len(outers))
+	for _, hole := range holes {
+		// Holes may touch the outer polygon, so look for a point that is
+		// clearly inside.
+		probe := hole.Centroid()
+		for _, p := range hole.Points() {
+			inside := false
+			for _, outer := range outers {
+				if outer.ContainsPt(p, FILL_RULE_NON_ZERO) == CONTAINMENT_INSIDE {
+					inside = true
+					break
+				}
+			}
+			if inside {
+				probe = p
+				break
+			}
+		}
+
+		best := -1
+		for h, outer := range outers {
+			if outer.ContainsPt(probe, FILL_RULE_NON_ZERO) == CONTAINMENT_OUTSIDE {
+				continue
+			}
+			if best < 0 || outer.Area() < outers[best].Area() {
+				best = h
+			}
+		}
+		if best >= 0 {
+			owned[best] = append(owned[best], hole)
+		}
+	}
+
+	regions := make([]Region, len(outers))
+	for h, outer := range outers {
+		regions[h] = RegionPolygon(outer, owned[h]...)
+	}
+	return MultiRegionFromRegions(regions...)
+}
+
+// Area returns the sum of the area of the regions.
+func (mr MultiRegion) Area() Length {
+	var sum Length
+	for _, r := range mr.regions {
+		sum += r.Area()
+	}
+	return sum
+}
+
+// BoundingBox returns an axis-aligned rectangle that encompasses all the
+// regions.
+func (mr MultiRegion) BoundingBox() Rectangle {
+	if len(mr.regions) == 0 {
+		return RectanglePt(PtNaN, PtNaN)
+	}
+	box := mr.regions[0].BoundingBox()
+	for _, r := range mr.regions[1:] {
+		box = RectangleAppend(box, r.BoundingBox())
+	}
+	return box
+}
+
+// ContainsPt returns if \c p is inside, outside, or on the boundary of any of
+// the regions. Inside is prioritized over boundary.
+func (mr MultiRegion) ContainsPt(p Pt) Containment {
+	c := CONTAINMENT_OUTSIDE
+	for _, r := range mr.regions {
+		switch r.ContainsPt(p) {
+		case CONTAINMENT_INSIDE:
+			return CONTAINMENT_INSIDE
+		case CONTAINMENT_BOUNDARY:
+			c = CONTAINMENT_BOUNDARY
+		}
+	}
+	return c
+}
+
+// OrErr returns a floating point error if any point of any region is in
+// error.
+func (mr MultiRegion) OrErr() (MultiRegion, *FloatingPointError) {
+	var err *FloatingPointError
+	for _, r := range mr.regions {
+		_, rerr := r.OrErr()
+		if rerr != nil && rerr.IsNaN() {
+			return mr, rerr
+		} else if rerr != nil {
+			err = rerr
+		}
+	}
+	return mr, err
+}
+
+// Perimeter returns the sum of the perimeter of the regions.
+func (mr MultiRegion) Perimeter() Length {
+	var sum Length
+	for _, r := range mr.regions {
+		sum += r.Perimeter()
+	}
+	return sum
+}
+
+// Regions provides access to the individual regions. Consider the regions
+// readonly.
+func (mr MultiRegion) Regions() []Region { return mr.regions[:] }
+
+// Rings returns the rings of every region. See \c Region.Rings.
+func (mr MultiRegion) Rings() []Polygon {
+	var rings []Polygon
+	for _, r := range mr.regions {
+		rings = append(rings, r.Rings()...)
+	}
+	return rings
+}
+
+// Rotate rotates the regions \c theta radians around \c origin.
+func (mr MultiRegion) Rotate(theta Radians, origin Pt) MultiRegion {
+	return mr.apply(func(r Region) Region { return r.Rotate(theta, origin) })
+}
+
+// Scale scales the coordinates of the regions by \c scalars.
+func (mr MultiRegion) Scale(scalars Vector) MultiRegion {
+	return mr.apply(func(r Region) Region { return r.Scale(scalars) })
+}
+
+// String returns the regions.
+func (mr MultiRegion) String() string {
+	var slice []string
+	for _, r := range mr.regions {
+		slice = append(slice, r.String())
+	}
+	return fmt.Sprintf("MultiRegion(%s)", strings.Join(slice, ", "))
+}
+
+// Translate moves the regions in the provided direction.
+func (mr MultiRegion) Translate(direction Vector) MultiRegion {
+	return mr.apply(func(r Region) Region { return r.Translate(direction) })
+}
+
+func (mr MultiRegion) apply(f func(Region) Region) MultiRegion {
+	regions := make([]Region, len(mr.regions))
+	for h, r := range mr.regions {
+		regions[h] = f(r)
+	}
+	return MultiRegionFromRegions(regions...)
+}
+
+// BooleanMultiRegion performs the boolean operation \c op between \c a and
+// \c b. The holes of both operands are respected.
+func BooleanMultiRegion(op BooleanOp, a, b MultiRegion) MultiRegion {
+	return MultiRegionFromRings(booleanRings(op, a.Rings(), b.Rings())...)
+}
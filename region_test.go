@@ -0,0 +1,240 @@
+package figuring
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRegion(t *testing.T) {
+	washer := RegionPolygon(
+		PolygonPt(PtXy(0, 0), PtXy(0, 10), PtXy(10, 10), PtXy(10, 0)),
+		PolygonPt(PtXy(3, 3), PtXy(7, 3), PtXy(7, 7), PtXy(3, 7)),
+	)
+	frame := RegionPolygon(
+		PolygonPt(PtXy(0, 0), PtXy(6, 0), PtXy(6, 4), PtXy(0, 4)),
+		PolygonPt(PtXy(1, 1), PtXy(2, 1), PtXy(2, 3), PtXy(1, 3)),
+		PolygonPt(PtXy(4, 1), PtXy(5, 1), PtXy(5, 3), PtXy(4, 3)),
+	)
+
+	identityTests := []struct {
+		a        Region
+		area     Length
+		perim    Length
+		min, max Pt
+		holes    int
+	}{
+		{washer, 84, 56, PtXy(0, 0), PtXy(10, 10), 1},
+		{frame, 20, 32, PtXy(0, 0), PtXy(6, 4), 2},
+		{RegionPolygon(Square), 1, 4, PtXy(0, 0), PtXy(1, 1), 0},
+		{RegionPolygon(Square, PolygonPt(PtXy(0.5, 0.5), PtXy(0.6, 0.6))), 1, 4, PtXy(0, 0), PtXy(1, 1), 0},
+	}
+	for h, test := range identityTests {
+		a := test.a
+		if area := a.Area(); !IsEqual(area, test.area) {
+			t.Errorf("[%d](%s).Area() failed. %f != %f",
+				h, a, area, test.area)
+		}
+		if perim := a.Perimeter(); !IsEqual(perim, test.perim) {
+			t.Errorf("[%d](%s).Perimeter() failed. %f != %f",
+				h, a, perim, test.perim)
+		}
+		box := a.BoundingBox()
+		if !IsEqualPair(box.MinPt(), test.min) || !IsEqualPair(box.MaxPt(), test.max) {
+			t.Errorf("[%d](%s).BoundingBox() failed. %v != (%v, %v)",
+				h, a, box, test.min, test.max)
+		}
+		if holes := a.Holes(); len(holes) != test.holes {
+			t.Errorf("[%d](%s).Holes() failed. %d != %d",
+				h, a, len(holes), test.holes)
+		}
+		if winding := a.Outer().Winding(); winding != WINDING_COUNTERCLOCKWISE {
+			t.Errorf("[%d](%s).Outer() failed (winding). %d != %d",
+				h, a, winding, WINDING_COUNTERCLOCKWISE)
+		}
+		for i, hole := range a.Holes() {
+			if winding := hole.Winding(); winding != WINDING_CLOCKWISE {
+				t.Errorf("[%d][%d](%s).Holes() failed (winding). %d != %d",
+					h, i, a, winding, WINDING_CLOCKWISE)
+			}
+		}
+	}
+
+	containsTests := []struct {
+		a Region
+		p Pt
+		c Containment
+	}{
+		{washer, PtXy(1, 1), CONTAINMENT_INSIDE},
+		{washer, PtXy(5, 5), CONTAINMENT_OUTSIDE},
+		{washer, PtXy(7, 5), CONTAINMENT_BOUNDARY},
+		{washer, PtXy(10, 5), CONTAINMENT_BOUNDARY},
+		{washer, PtXy(11, 5), CONTAINMENT_OUTSIDE},
+		{frame, PtXy(3, 2), CONTAINMENT_INSIDE},
+		{frame, PtXy(4.5, 2), CONTAINMENT_OUTSIDE},
+	}
+	for h, test := range containsTests {
+		a := test.a
+		if c := a.ContainsPt(test.p); c != test.c {
+			t.Errorf("[%d](%s).ContainsPt(%v) failed. %d != %d",
+				h, a, test.p, c, test.c)
+		}
+	}
+
+	transformTests := []struct {
+		a        Region
+		b        Region
+		min, max Pt
+	}{
+		{washer.Translate(VectorIj(5, -5)), washer, PtXy(5, -5), PtXy(15, 5)},
+		{washer.Rotate(math.Pi/2, PtOrig), washer, PtXy(-10, 0), PtXy(0, 10)},
+		{washer.Scale(VectorIj(2, 3)), washer.Scale(VectorIj(2, 3)), PtXy(0, 0), PtXy(20, 30)},
+		{washer.Scale(VectorIj(-1, 1)), washer, PtXy(-10, 0), PtXy(0, 10)},
+	}
+	for h, test := range transformTests {
+		a := test.a
+		box := a.BoundingBox()
+		if !IsEqualPair(box.MinPt(), test.min) || !IsEqualPair(box.MaxPt(), test.max) {
+			t.Errorf("[%d](%s) transform failed. %v != (%v, %v)",
+				h, a, box, test.min, test.max)
+		}
+		if area := a.Area(); !IsEqual(area, test.b.Area()) {
+			t.Errorf("[%d](%s) transform failed (area). %f != %f",
+				h, a, area, test.b.Area())
+		}
+		if winding := a.Outer().Winding(); winding != WINDING_COUNTERCLOCKWISE {
+			t.Errorf("[%d](%s) transform failed (winding). %d != %d",
+				h, a, winding, WINDING_COUNTERCLOCKWISE)
+		}
+	}
+
+	errorTests := []struct {
+		a     Region
+		isErr bool
+	}{
+		{washer, false},
+		{RegionPolygon(Square, PolygonPt(PtXy(0.2, 0.2), PtXy(Length(math.NaN()), 0.5), PtXy(0.5, 0.8))), true},
+		{RegionPolygon(PolygonPt(PtXy(0, 0), PtXy(Length(math.Inf(1)), 0), PtXy(0, 1))), true},
+	}
+	for h, test := range errorTests {
+		a := test.a
+		_, err := a.OrErr()
+		if (err != nil) != test.isErr {
+			t.Errorf("[%d](%v).OrErr() failed. %t != %t. %v",
+				h, test.a, (err != nil), test.isErr, err)
+		}
+	}
+}
+
+func TestMultiRegion(t *testing.T) {
+	square := PolygonPt(PtXy(0, 0), PtXy(4, 0), PtXy(4, 4), PtXy(0, 4))
+	inner := PolygonPt(PtXy(1, 1), PtXy(3, 1), PtXy(3, 3), PtXy(1, 3))
+	island := PolygonPt(PtXy(1.5, 1.5), PtXy(2.5, 1.5), PtXy(2.5, 2.5), PtXy(1.5, 2.5))
+	far := PolygonPt(PtXy(10, 10), PtXy(12, 10), PtXy(12, 12), PtXy(10, 12))
+
+	ringTests := []struct {
+		rings   []Polygon
+		regions int
+		holes   []int
+		area    Length
+	}{
+		{
+			[]Polygon{square, inner.Reverse(), island},
+			2, []int{1, 0}, 13,
+		}, {
+			[]Polygon{inner.Reverse(), far, square},
+			2, []int{0, 1}, 16,
+		}, {
+			[]Polygon{far.Reverse()},
+			0, []int{}, 0,
+		},
+	}
+	for h, test := range ringTests {
+		mr := MultiRegionFromRings(test.rings...)
+		regions := mr.Regions()
+		if len(regions) != test.regions {
+			t.Fatalf("[%d]MultiRegionFromRings(%v) failed. %d != %d",
+				h, test.rings, len(regions), test.regions)
+		}
+		for i, r := range regions {
+			if holes := len(r.Holes()); holes != test.holes[i] {
+				t.Errorf("[%d][%d]MultiRegionFromRings(%v) failed (holes). %d != %d",
+					h, i, test.rings, holes, test.holes[i])
+			}
+		}
+		if area := mr.Area(); !IsEqual(area, test.area) {
+			t.Errorf("[%d]MultiRegionFromRings(%v) failed (area). %f != %f",
+				h, test.rings, area, test.area)
+		}
+	}
+
+	mr := MultiRegionFromRegions(RegionPolygon(square, inner), RegionPolygon(far))
+	if area := mr.Area(); !IsEqual(area, 16) {
+		t.Errorf("(%s).Area() failed. %f != %f", mr, area, 16.)
+	}
+	if perim := mr.Perimeter(); !IsEqual(perim, 32) {
+		t.Errorf("(%s).Perimeter() failed. %f != %f", mr, perim, 32.)
+	}
+	if box := mr.BoundingBox(); !IsEqualPair(box.MinPt(), PtOrig) || !IsEqualPair(box.MaxPt(), PtXy(12, 12)) {
+		t.Errorf("(%s).BoundingBox() failed. %v", mr, box)
+	}
+	if box := mr.Translate(VectorIj(1, 1)).BoundingBox(); !IsEqualPair(box.MinPt(), PtXy(1, 1)) {
+		t.Errorf("(%s).Translate() failed. %v", mr, box)
+	}
+	if box := mr.Scale(VectorIj(0.5, 0.5)).BoundingBox(); !IsEqualPair(box.MaxPt(), PtXy(6, 6)) {
+		t.Errorf("(%s).Scale() failed. %v", mr, box)
+	}
+	if box := mr.Rotate(math.Pi, PtOrig).BoundingBox(); !IsEqualPair(box.MinPt(), PtXy(-12, -12)) {
+		t.Errorf("(%s).Rotate() failed. %v", mr, box)
+	}
+	containsTests := []struct {
+		p Pt
+		c Containment
+	}{
+		{PtXy(0.5, 0.5), CONTAINMENT_INSIDE},
+		{PtXy(2, 2), CONTAINMENT_OUTSIDE},
+		{PtXy(11, 11), CONTAINMENT_INSIDE},
+		{PtXy(12, 11), CONTAINMENT_BOUNDARY},
+		{PtXy(8, 8), CONTAINMENT_OUTSIDE},
+	}
+	for h, test := range containsTests {
+		if c := mr.ContainsPt(test.p); c != test.c {
+			t.Errorf("[%d](%s).ContainsPt(%v) failed. %d != %d",
+				h, mr, test.p, c, test.c)
+		}
+	}
+
+	booleanTests := []struct {
+		op      BooleanOp
+		a, b    MultiRegion
+		regions int
+		area    Length
+	}{
+		{
+			BOOLEAN_OP_UNION,
+			MultiRegionFromRegions(RegionPolygon(square, inner)),
+			MultiRegionFromRegions(RegionPolygon(island)),
+			2, 13,
+		}, {
+			BOOLEAN_OP_INTERSECTION,
+			MultiRegionFromRegions(RegionPolygon(square, inner)),
+			MultiRegionFromRegions(RegionPolygon(PolygonPt(PtXy(2, -1), PtXy(5, -1), PtXy(5, 5), PtXy(2, 5)))),
+			1, 6,
+		}, {
+			BOOLEAN_OP_DIFFERENCE,
+			MultiRegionFromRegions(RegionPolygon(square)),
+			MultiRegionFromRegions(RegionPolygon(inner), RegionPolygon(far)),
+			1, 12,
+		},
+	}
+	for h, test := range booleanTests {
+		result := BooleanMultiRegion(test.op, test.a, test.b)
+		if regions := len(result.Regions()); regions != test.regions {
+			t.Errorf("[%d]BooleanMultiRegion(%d, %v, %v) failed. %d != %d",
+				h, test.op, test.a, test.b, regions, test.regions)
+		}
+		if area := result.Area(); !IsEqual(area, test.area) {
+			t.Errorf("[%d]BooleanMultiRegion(%d, %v, %v) failed (area). %f != %f",
+				h, test.op, test.a, test.b, area, test.area)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package figuring
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// rootIterations is the largest number of steps used to isolate or
+	// polish a single root.
+	rootIterations = 200
+	// rootTolerance is the size of a value, relative to the terms that
+	// produced it, that is treated as zero while checking a repeated root.
+	// It is tighter than \c polynomialGCDTolerance, so nearby roots that the
+	// greatest common divisor merged are caught.
+	rootTolerance = 1e-13
+	// rootMaxDepth limits how many times a cluster of merged roots is
+	// separated again.
+	rootMaxDepth = 8
+)
+
+// Root is a real root of a polynomial.
+type Root struct {
+	// T is where the polynomial is equal to zero.
+	T float64
+	// Multiplicity is the number of times the root is repeated. A root
+	// where the polynomial only touches zero has an even multiplicity.
+	Multiplicity int
+}
+
+// RealRoots returns the real roots of \c eq between \c lo and \c hi,
+// inclusive, sorted from least to greatest. See \c PolynomialN.RealRoots.
+func RealRoots(eq Coefficienter, lo, hi float64) []Root {
+	return PolynomialNFrom(eq).RealRoots(lo, hi)
+}
+
+// RealRoots returns the real roots of the polynomial between \c lo and \c hi,
+// inclusive, sorted from least to greatest. Unlike the closed form \c Roots of
+// \c Cubic and \c Quartic, it works for any degree and stays accurate near
+// repeated roots.
+//
+// The polynomial is split into square-free factors, one for each
+// multiplicity. The roots of each factor are isolated with a Sturm sequence,
+// then polished against the polynomial with Newton's method, falling back to
+// bisection. A repeated root is only kept if the polynomial and its
+// derivatives are zero there, otherwise the nearby roots that were merged
+// into it are found separately. Leading terms that are lost in the rounding
+// of the others over the whole range are ignored. The zero polynomial has no
+// roots.
+func (p PolynomialN) RealRoots(lo, hi float64) []Root {
+	roots := p.withinRange(lo, hi).realRoots(lo, hi, 0)
+	sort.Slice(roots, func(i, j int) bool { return roots[i].T < roots[j].T })
+	return roots
+}
+
+// withinRange returns the polynomial without the leading terms that are lost
+// in the rounding of the other terms everywhere between \c lo and \c hi.
+// Those terms are usually left over from cancellation, and only add roots
+// far outside of the range.
+func (p PolynomialN) withinRange(lo, hi float64) PolynomialN {
+	terms := p.terms()
+	m := math.Max(1, math.Max(math.Abs(lo), math.Abs(hi)))
+	var size float64
+	for _, c := range terms {
+		size = size*m + math.Abs(c)
+	}
+	for len(terms) > 1 && math.Abs(terms[0])*math.Pow(m, float64(len(terms)-1)) <= 0x1p-52*size {
+		terms = terms[1:]
+	}
+	return polynomialN(terms)
+}
+
+// realRoots returns the unsorted real roots of the polynomial between \c lo
+// and \c hi. The \c depth counts the clusters being separated.
+func (p PolynomialN) realRoots(lo, hi float64, depth int) []Root {
+	if p.Degree() < 1 || hi < lo {
+		return nil
+	}
+	var roots []Root
+	for h, factor := range p.squareFree() {
+		if factor.Degree() < 1 {
+			continue
+		}
+		// A root with a multiplicity of h+1 is a simple root of derivative
+		// h, so it is polished there instead of on the approximate factor.
+		deriv := p
+		for i := 0; i < h; i++ {
+			deriv = deriv.FirstDerivative()
+		}
+		for _, t := range factor.simpleRoots(lo, hi) {
+			if polished := deriv.refineRoot(t); lo <= polished && polished <= hi {
+				t = polished
+			}
+			if h == 0 || p.hasRootAt(t, h+1) || depth >= rootMaxDepth {
+				roots = append(roots, Root{T: t, Multiplicity: h + 1})
+				continue
+			}
+			roots = append(roots, p.clusterRoots(t, h+1, lo, hi, depth)...)
+		}
+	}
+	return roots
+}
+
+// clusterRoots returns the roots of the polynomial near \c t, where the
+// greatest common divisor merged \c count roots that are close together but
+// not repeated. Near \c t, the polynomial is dominated by the terms of its
+// Taylor series up to \c count, so the roots of those terms are polished
+// against the polynomial.
+func (p PolynomialN) clusterRoots(t float64, count int, lo, hi float64, depth int) []Root {
+	shifted := p.shifted(t).terms()
+	local := PolynomialNCoefficients(shifted[len(shifted)-count-1:]...)
+	var roots []Root
+	for _, root := range local.realRoots(lo-t, hi-t, depth+1) {
+		polished := p.refineRoot(t + root.T)
+		if polished < lo || hi < polished || !p.hasRootAt(polished, 1) {
+			continue
+		}
+		roots = append(roots, Root{T: polished, Multiplicity: root.Multiplicity})
+	}
+	return roots
+}
+
+// hasRootAt returns true if the polynomial and its first \c count-1
+// derivatives are zero at \c t, within the rounding of their terms.
+func (p PolynomialN) hasRootAt(t float64, count int) bool {
+	eq := p
+	for h := 0; h < count; h++ {
+		var v, size float64
+		for _, c := range eq.terms() {
+			v = math.FMA(v, t, c)
+			size = size*math.Abs(t) + math.Abs(c)
+		}
+		if math.Abs(v) > rootTolerance*size {
+			return false
+		}
+		eq = eq.FirstDerivative()
+	}
+	return true
+}
+
+// refineRoot returns \c t moved toward a nearby root with Newton's method.
+// Steps are only taken while they get closer to zero.
+func (p PolynomialN) refineRoot(t float64) float64 {
+	deriv := p.FirstDerivative()
+	ft := math.Abs(p.AtT(t))
+	for h := 0; h < rootIterations && ft != 0; h++ {
+		next := t - p.AtT(t)/deriv.AtT(t)
+		fn := math.Abs(p.AtT(next))
+		if !(fn < ft) {
+			break
+		}
+		t, ft = next, fn
+	}
+	return t
+}
+
+// shifted returns the polynomial moved by \c t, so that shifted(s) is equal
+// to p(s+t). The coefficients are the Taylor series of the polynomial at
+// \c t.
+func (p PolynomialN) shifted(t float64) PolynomialN {
+	c := append([]float64{}, p.terms()...)
+	n := len(c) - 1
+	for h := 0; h < n; h++ {
+		for i := 1; i <= n-h; i++ {
+			c[i] = math.FMA(c[i-1], t, c[i])
+		}
+	}
+	return polynomialN(c)
+}
+
+// squareFree returns the square-free factors of the polynomial, using Yun's
+// algorithm. The factor at index h has the roots with a multiplicity of h+1,
+// and none of the factors have repeated roots.
+func (p PolynomialN) squareFree() []PolynomialN {
+	// see https://en.wikipedia.org/wiki/Square-free_polynomial#Yun's_algorithm
+	f := p.Monic()
+	df := f.FirstDerivative()
+	a := f.GCD(df)
+	b, _ := f.Divide(a)
+	c, _ := df.Divide(a)
+	var factors []PolynomialN
+	for b.Degree() > 0 && len(factors) < p.Degree() {
+		d := c.subRounded(b.FirstDerivative(), polynomialGCDTolerance)
+		a = b.GCD(d)
+		factors = append(factors, a)
+		b, _ = b.Divide(a)
+		c, _ = d.Divide(a)
+	}
+	if b.Degree() > 0 && len(factors) > 0 {
+		// Rounding left in \c b hid a factor from the greatest common
+		// divisor, so keep its roots with the simple roots.
+		factors[0] = factors[0].Mul(b.Monic())
+	}
+	return factors
+}
+
+// sturm returns the Sturm sequence of the polynomial, which must not have
+// repeated roots.
+func (p PolynomialN) sturm() []PolynomialN {
+	// see https://en.wikipedia.org/wiki/Sturm%27s_theorem
+	seq := []PolynomialN{p, p.FirstDerivative()}
+	for {
+		a, b := seq[len(seq)-2], seq[len(seq)-1]
+		if b.Degree() < 1 {
+			return seq
+		}
+		r := a.remainder(b, polynomialGCDTolerance)
+		if r.IsZero() {
+			return seq
+		}
+		seq = append(seq, r.Scale(-1))
+	}
+}
+
+// sturmChanges returns the number of sign changes of the Sturm sequence
+// \c seq at \c t. Zeros are skipped.
+func sturmChanges(seq []PolynomialN, t float64) int {
+	var changes int
+	var prev float64
+	for _, eq := range seq {
+		v := eq.AtT(t)
+		if v == 0 {
+			continue
+		}
+		if prev != 0 && math.Signbit(v) != math.Signbit(prev) {
+			changes++
+		}
+		prev = v
+	}
+	return changes
+}
+
+// simpleRoots returns the roots of a polynomial without repeated roots
+// between \c lo and \c hi, inclusive, sorted from least to greatest.
+func (p PolynomialN) simpleRoots(lo, hi float64) []float64 {
+	seq := p.sturm()
+	var roots []float64
+	if p.AtT(lo) == 0 {
+		roots = append(roots, lo)
+	}
+
+	// Sturm's theorem counts the distinct roots in (a, b]. Split the range
+	// until each piece has a single root.
+	var isolate func(a, b float64, va, vb, depth int)
+	isolate = func(a, b float64, va, vb, depth int) {
+		count := va - vb
+		if count <= 0 {
+			return
+		} else if count == 1 || depth >= rootIterations || b-a <= 1e-15*math.Max(1, math.Abs(a)) {
+			roots = append(roots, p.polishRoot(seq, a, b))
+			return
+		}
+		mid := a + (b-a)/2
+		vm := sturmChanges(seq, mid)
+		isolate(a, mid, va, vm, depth+1)
+		isolate(mid, b, vm, vb, depth+1)
+	}
+	isolate(lo, hi, sturmChanges(seq, lo), sturmChanges(seq, hi), 0)
+	return roots
+}
+
+// polishRoot returns the single root of the polynomial in (a, b]. The range
+// is narrowed with the Sturm sequence \c seq until the polynomial changes
+// sign, then the root is found with Newton's method, falling back to
+// bisection when a step leaves the range.
+func (p PolynomialN) polishRoot(seq []PolynomialN, a, b float64) float64 {
+	// see https://en.wikipedia.org/wiki/Newton%27s_method
+	fa, fb := p.AtT(a), p.AtT(b)
+	if fb == 0 {
+		return b
+	}
+	for h := 0; h < rootIterations && (fa == 0 || math.Signbit(fa) == math.Signbit(fb)); h++ {
+		mid := a + (b-a)/2
+		if mid <= a || mid >= b {
+			return mid
+		}
+		if sturmChanges(seq, a)-sturmChanges(seq, mid) > 0 {
+			b, fb = mid, p.AtT(mid)
+			if fb == 0 {
+				return b
+			}
+		} else {
+			a, fa = mid, p.AtT(mid)
+		}
+	}
+
+	deriv := p.FirstDerivative()
+	t := a + (b-a)/2
+	for h := 0; h < rootIterations; h++ {
+		ft := p.AtT(t)
+		if ft == 0 {
+			return t
+		} else if math.Signbit(ft) == math.Signbit(fa) {
+			a, fa = t, ft
+		} else {
+			b = t
+		}
+		next := t - ft/deriv.AtT(t)
+		if !(a < next && next < b) {
+			next = a + (b-a)/2
+		}
+		if math.Abs(next-t) <= 1e-15*math.Max(1, math.Abs(t)) {
+			return next
+		}
+		t = next
+	}
+	return t
+}
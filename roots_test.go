@@ -0,0 +1,120 @@
+package figuring
+
+import (
+	"testing"
+)
+
+func TestRealRoots(t *testing.T) {
+	pn := PolynomialNCoefficients
+	tests := []struct {
+		eq     PolynomialN
+		lo, hi float64
+		roots  []Root
+	}{
+		{
+			//0
+			// (t-1)^2(t+2)
+			pn(1, 0, -3, 2), -10, 10,
+			[]Root{{-2, 1}, {1, 2}},
+		}, {
+			// (t-0.5)^3(t-0.25)
+			pn(1, -0.5).Mul(pn(1, -0.5)).Mul(pn(1, -0.5)).Mul(pn(1, -0.25)), 0, 1,
+			[]Root{{0.25, 1}, {0.5, 3}},
+		}, {
+			// t^2(t-1), roots on both ends of the range.
+			pn(1, -1, 0, 0), 0, 1,
+			[]Root{{0, 2}, {1, 1}},
+		}, {
+			// (t+1)(t-0.5), only one root in the range.
+			pn(1, 0.5, -0.5), 0, 1,
+			[]Root{{0.5, 1}},
+		}, {
+			// (t-1)(t-2)(t-3)(t-4)(t-5)(t-6)(t-7)
+			pn(1, -1).Mul(pn(1, -2)).Mul(pn(1, -3)).Mul(pn(1, -4)).Mul(pn(1, -5)).Mul(pn(1, -6)).Mul(pn(1, -7)), 0, 10,
+			[]Root{{1, 1}, {2, 1}, {3, 1}, {4, 1}, {5, 1}, {6, 1}, {7, 1}},
+		}, {
+			//5
+			// Close roots stay separate.
+			pn(1, -0.3).Mul(pn(1, -0.3001)), 0, 1,
+			[]Root{{0.3, 1}, {0.3001, 1}},
+		}, {
+			// (t^2+1)(t-0.75)^2
+			pn(1, 0, 1).Mul(pn(1, -0.75)).Mul(pn(1, -0.75)), 0, 1,
+			[]Root{{0.75, 2}},
+		}, {
+			pn(1, 0, 1), -10, 10,
+			nil,
+		}, {
+			// Nearly repeated roots are not merged.
+			pn(1, -0.5).Mul(pn(1, -0.50001)), 0, 1,
+			[]Root{{0.5, 1}, {0.50001, 1}},
+		}, {
+			pn(1, 0, 1e-12), -1, 1,
+			nil,
+		}, {
+			//10
+			pn(1, 0, 0, 0, 0, -1e-20), -1, 1,
+			[]Root{{1e-4, 1}},
+		}, {
+			// (t-0.2)^2(t-0.5)(t-0.50001)
+			pn(1, -0.2).Mul(pn(1, -0.2)).Mul(pn(1, -0.5)).Mul(pn(1, -0.50001)), 0, 1,
+			[]Root{{0.2, 2}, {0.5, 1}, {0.50001, 1}},
+		}, {
+			pn(1, -0.5).Mul(pn(1, -0.5001)).Mul(pn(1, -0.5002)), 0, 1,
+			[]Root{{0.5, 1}, {0.5001, 1}, {0.5002, 1}},
+		}, {
+			// A repeated factor without real roots keeps the simple roots.
+			// (t^2+1)^2(t-1)
+			pn(1, 0, 1).Mul(pn(1, 0, 1)).Mul(pn(1, -1)), -10, 10,
+			[]Root{{1, 1}},
+		}, {
+			// (t^2+2)^2(t-0.5)
+			pn(1, 0, 2).Mul(pn(1, 0, 2)).Mul(pn(1, -0.5)), -10, 10,
+			[]Root{{0.5, 1}},
+		}, {
+			//15
+			// (t^2+1)^3(t-1)(t-2)^2
+			pn(1, 0, 1).Mul(pn(1, 0, 1)).Mul(pn(1, 0, 1)).Mul(pn(1, -1)).Mul(pn(1, -2)).Mul(pn(1, -2)), -10, 10,
+			[]Root{{1, 1}, {2, 2}},
+		}, {
+			// Rounding left in the constant doesn't hide the simple roots.
+			// (t-1)^3 t(t-0.25)(t^2+4) - 1e-15
+			pn(1, -1).Mul(pn(1, -1)).Mul(pn(1, -1)).Mul(pn(1, 0)).Mul(pn(1, -0.25)).Mul(pn(1, 0, 4)).Sub(pn(1e-15)), -1, 2,
+			[]Root{{0, 1}, {0.25, 1}, {1, 3}},
+		}, {
+			pn(3), -10, 10,
+			nil,
+		}, {
+			pn(1, -2), 1, 0,
+			nil,
+		},
+	}
+	for h, test := range tests {
+		roots := test.eq.RealRoots(test.lo, test.hi)
+		if len(roots) != len(test.roots) {
+			t.Errorf("[%d](%v).RealRoots(%f, %f) length failed. %v != %v",
+				h, test.eq, test.lo, test.hi, roots, test.roots)
+			continue
+		}
+		for i := range roots {
+			if !IsEqual(roots[i].T, test.roots[i].T) || roots[i].Multiplicity != test.roots[i].Multiplicity {
+				t.Errorf("[%d][%d](%v).RealRoots(%f, %f) failed. %v != %v",
+					h, i, test.eq, test.lo, test.hi, roots[i], test.roots[i])
+			}
+		}
+	}
+
+	// A leading term lost in the rounding of the others, as left by
+	// cancellation, doesn't hide the roots in the range.
+	noisy := pn(1.438849039914203e-13, -2472.912297676567, 8655.193041867984, -22256.21067908909,
+		34002.544093052784, -28902.162479094866, 13678.296146523502, -2704.7478255837423, 0)
+	if roots := noisy.RealRoots(0.25, 0.75); len(roots) != 1 || !IsEqual(roots[0].T, 0.5) || roots[0].Multiplicity != 1 {
+		t.Errorf("(%v).RealRoots(0.25, 0.75) failed. %v != [{0.5 1}]", noisy, roots)
+	}
+
+	// The fixed degree types use the same solver.
+	cubic := CubicAbcd(1, -3, 3, -1)
+	if roots := RealRoots(cubic, 0, 2); len(roots) != 1 || !IsEqual(roots[0].T, 1) || roots[0].Multiplicity != 3 {
+		t.Errorf("RealRoots(%v, 0, 2) failed. %v != [{1 3}]", cubic, roots)
+	}
+}
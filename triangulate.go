@@ -0,0 +1,283 @@
+package figuring
+
+import (
+	"math"
+	"sort"
+)
+
+// TriangulatePolygon splits the polygon into triangles by ear clipping. The
+// triangles are returned as counter-clockwise indexes into the points of the
+// polygon. The winding of the polygon is ignored. Polygons without area have
+// no triangles.
+//
+// Ear clipping is quick, but tends to produce long thin triangles. See
+// \c TriangulateDelaunayPolygon for better shaped triangles.
+func TriangulatePolygon(poly Polygon) [][3]int {
+	outer := ringIndexes(poly, 0, WINDING_COUNTERCLOCKWISE)
+	if outer == nil {
+		return nil
+	}
+	return earClip(poly.Points(), outer, nil)
+}
+
+// TriangulateRegion splits the region into triangles by ear clipping. The
+// holes are first joined to the outer polygon by bridges. The triangles are
+// returned as counter-clockwise indexes into \c Region.Points.
+func TriangulateRegion(r Region) [][3]int {
+	outer := ringIndexes(r.outer, 0, WINDING_COUNTERCLOCKWISE)
+	if outer == nil {
+		return nil
+	}
+	offset := len(r.outer.Points())
+	holes := make([][]int, 0, len(r.holes))
+	for _, hole := range r.holes {
+		if idx := ringIndexes(hole, offset, WINDING_CLOCKWISE); idx != nil {
+			holes = append(holes, idx)
+		}
+		offset += len(hole.Points())
+	}
+	return earClip(r.Points(), outer, holes)
+}
+
+// TriangulateDelaunayPolygon splits the polygon into triangles using a
+// constrained Delaunay triangulation. Every side of the polygon is a side of
+// a triangle, and otherwise the triangles are as close to equilateral as the
+// points allow. The triangles are returned as counter-clockwise indexes into
+// the points of the polygon. Duplicate points use the index of the first
+// copy.
+func TriangulateDelaunayPolygon(poly Polygon) [][3]int {
+	if poly.Winding() == WINDING_NONE {
+		return nil
+	}
+	return delaunayDomain(poly.Points(), []Polygon{poly}, func(p Pt) bool {
+		return poly.ContainsPt(p, FILL_RULE_NON_ZERO) == CONTAINMENT_INSIDE
+	})
+}
+
+// TriangulateDelaunayRegion splits the region into triangles using a
+// constrained Delaunay triangulation. See \c TriangulateDelaunayPolygon. The
+// triangles are returned as counter-clockwise indexes into \c Region.Points.
+func TriangulateDelaunayRegion(r Region) [][3]int {
+	if r.outer.Winding() == WINDING_NONE {
+		return nil
+	}
+	return delaunayDomain(r.Points(), r.Rings(), func(p Pt) bool {
+		return r.ContainsPt(p) == CONTAINMENT_INSIDE
+	})
+}
+
+// TrianglesFromIndexes converts the indexes returned by the triangulate
+// functions into polygons.
+func TrianglesFromIndexes(pts []Pt, tris [][3]int) []Polygon {
+	polys := make([]Polygon, len(tris))
+	for h, tri := range tris {
+		polys[h] = PolygonPt(pts[tri[0]], pts[tri[1]], pts[tri[2]])
+	}
+	return polys
+}
+
+// ringIndexes returns the indexes of the points of \c ring, starting at
+// \c offset, ordered to have \c winding. Returns nil for rings without area.
+func ringIndexes(ring Polygon, offset int, winding Winding) []int {
+	w := ring.Winding()
+	if w == WINDING_NONE {
+		return nil
+	}
+	n := len(ring.Points())
+	idx := make([]int, n)
+	for h := range idx {
+		if w == winding {
+			idx[h] = offset + h
+		} else {
+			idx[h] = offset + n - 1 - h
+		}
+	}
+	return idx
+}
+
+// earClip triangulates the counter-clockwise ring \c outer with the
+// clockwise \c holes cut out of it.
+func earClip(pts []Pt, outer []int, holes [][]int) [][3]int {
+	// see https://www.geometrictools.com/Documentation/TriangulationByEarClipping.pdf
+	maxX := func(hole []int) (int, Length) {
+		best := 0
+		for h, v := range hole {
+			if pts[v].X() > pts[hole[best]].X() {
+				best = h
+			}
+		}
+		return best, pts[hole[best]].X()
+	}
+
+	// Bridge the holes furthest to the right first, so each bridge can only
+	// cross holes that are already part of the ring.
+	sort.SliceStable(holes, func(i, j int) bool {
+		_, xi := maxX(holes[i])
+		_, xj := maxX(holes[j])
+		return xi > xj
+	})
+	ring := append([]int{}, outer...)
+	for _, hole := range holes {
+		m, _ := maxX(hole)
+		ring = bridgeHole(pts, ring, hole, m)
+	}
+	return clipEars(pts, ring)
+}
+
+// bridgeHole joins \c hole to \c ring with a pair of sides between the hole
+// point at index \c m and a point of the ring visible from it.
+func bridgeHole(pts []Pt, ring, hole []int, m int) []int {
+	pm := pts[hole[m]]
+	mx, my := pm.XY()
+
+	// Cast a ray to the right and find the closest side it hits. The filled
+	// area is left of the sides, so only sides heading up face the ray.
+	n := len(ring)
+	side, closest := -1, Length(math.Inf(1))
+	for h := 0; h < n; h++ {
+		a, b := pts[ring[h]], pts[ring[(h+1)%n]]
+		if !(a.Y() <= my && my <= b.Y()) || a.Y() == b.Y() {
+			continue
+		}
+		x := a.X() + (my-a.Y())*(b.X()-a.X())/(b.Y()-a.Y())
+		if x >= mx && x < closest {
+			side, closest = h, x
+		}
+	}
+	if side < 0 {
+		// The hole is not inside the ring.
+		return ring
+	}
+
+	// The side's point furthest right is visible unless other points are
+	// inside the triangle between it, the hole, and the ray.
+	hit := PtXy(closest, my)
+	best := side
+	if pts[ring[(side+1)%n]].X() > pts[ring[side]].X() {
+		best = (side + 1) % n
+	}
+	for _, h := range []int{side, (side + 1) % n} {
+		if IsEqualPair(pts[ring[h]], hit) {
+			best = h
+		}
+	}
+	pb := pts[ring[best]]
+	if !IsEqualPair(pb, hit) {
+		a, b, c := pm, hit, pb
+		if orientPts(a, b, c) < 0 {
+			b, c = c, b
+		}
+		angle := func(p Pt) (Radians, Length) {
+			v := pm.VectorTo(p)
+			return Radians(math.Abs(math.Atan2(float64(v.ij[1]), float64(v.ij[0])))), v.Magnitude()
+		}
+		bestAngle, bestDist := angle(pb)
+		for h, v := range ring {
+			p := pts[v]
+			if IsEqualPair(p, pb) || !isPtInTriangle(p, a, b, c) {
+				continue
+			}
+			if theta, dist := angle(p); theta < bestAngle || (theta == bestAngle && dist < bestDist) {
+				best, bestAngle, bestDist = h, theta, dist
+			}
+		}
+	}
+
+	joined := make([]int, 0, len(ring)+len(hole)+2)
+	joined = append(joined, ring[:best+1]...)
+	for h := 0; h <= len(hole); h++ {
+		joined = append(joined, hole[(m+h)%len(hole)])
+	}
+	return append(joined, ring[best:]...)
+}
+
+// clipEars repeatedly removes a corner of the counter-clockwise ring that
+// contains no other points.
+func clipEars(pts []Pt, ring []int) [][3]int {
+	var tris [][3]int
+	isEar := func(h int) bool {
+		n := len(ring)
+		a, b, c := pts[ring[(h+n-1)%n]], pts[ring[h]], pts[ring[(h+1)%n]]
+		if orientPts(a, b, c) <= 0 {
+			return false
+		}
+		for _, v := range ring {
+			p := pts[v]
+			if IsEqualPair(p, a) || IsEqualPair(p, b) || IsEqualPair(p, c) {
+				continue
+			}
+			if isPtInTriangle(p, a, b, c) {
+				return false
+			}
+		}
+		return true
+	}
+	remove := func(h int) {
+		ring = append(ring[:h], ring[h+1:]...)
+	}
+
+	for h, misses := 0, 0; len(ring) > 3; {
+		n := len(ring)
+		h %= n
+		if misses < n {
+			if isEar(h) {
+				tris = append(tris, [3]int{ring[(h+n-1)%n], ring[h], ring[(h+1)%n]})
+				remove(h)
+				h, misses = (h+n-2)%(n-1), 0
+			} else {
+				h, misses = h+1, misses+1
+			}
+			continue
+		}
+
+		// No ears are left, which only happens when points are collinear
+		// or repeated. Drop one of those points and carry on.
+		dropped := false
+		for i := 0; i < n && !dropped; i++ {
+			a, b, c := pts[ring[(i+n-1)%n]], pts[ring[i]], pts[ring[(i+1)%n]]
+			if orientPts(a, b, c) == 0 {
+				remove(i)
+				dropped = true
+			}
+		}
+		if !dropped {
+			break
+		}
+		misses = 0
+	}
+	if len(ring) == 3 && orientPts(pts[ring[0]], pts[ring[1]], pts[ring[2]]) > 0 {
+		tris = append(tris, [3]int{ring[0], ring[1], ring[2]})
+	}
+	return tris
+}
+
+// isPtInTriangle returns if \c p is inside or on the boundary of the
+// counter-clockwise triangle \c a, \c b, \c c.
+func isPtInTriangle(p, a, b, c Pt) bool {
+	return orientPts(a, b, p) >= 0 && orientPts(b, c, p) >= 0 && orientPts(c, a, p) >= 0
+}
+
+// delaunayDomain triangulates \c pts with the sides of \c rings kept, and
+// returns the triangles whose centroid passes \c inside. The indexes of
+// \c pts must match the points of \c rings in order.
+func delaunayDomain(pts []Pt, rings []Polygon, inside func(Pt) bool) [][3]int {
+	m, vertex := delaunayMesh(pts)
+	offset := 0
+	for _, ring := range rings {
+		n := len(ring.Points())
+		for h := 0; h < n; h++ {
+			m.constrain(vertex[offset+h], vertex[offset+(h+1)%n])
+		}
+		offset += n
+	}
+
+	var tris [][3]int
+	for _, tri := range m.triangles() {
+		a, b, c := pts[tri[0]], pts[tri[1]], pts[tri[2]]
+		centroid := PtXy((a.X()+b.X()+c.X())/3, (a.Y()+b.Y()+c.Y())/3)
+		if inside(centroid) {
+			tris = append(tris, tri)
+		}
+	}
+	return tris
+}
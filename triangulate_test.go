@@ -0,0 +1,102 @@
+package figuring
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTriangulate(t *testing.T) {
+	square := PolygonPt(PtXy(0, 0), PtXy(4, 0), PtXy(4, 4), PtXy(0, 4))
+	ell := PolygonPt(PtXy(0, 0), PtXy(4, 0), PtXy(4, 1), PtXy(1, 1), PtXy(1, 4), PtXy(0, 4))
+	comb := PolygonPt(PtXy(0, 0), PtXy(5, 0), PtXy(5, 3), PtXy(4, 3), PtXy(4, 1), PtXy(3, 1),
+		PtXy(3, 3), PtXy(2, 3), PtXy(2, 1), PtXy(1, 1), PtXy(1, 3), PtXy(0, 3))
+	var round []Pt
+	for h := 0; h < 24; h++ {
+		theta := 2 * math.Pi * float64(h) / 24
+		round = append(round, PtXy(Length(10*math.Cos(theta)), Length(10*math.Sin(theta))))
+	}
+	circle := PolygonPt(round...)
+	inner := PolygonPt(PtXy(1, 1), PtXy(3, 1), PtXy(3, 3), PtXy(1, 3))
+	left := PolygonPt(PtXy(0.5, 0.5), PtXy(1.5, 0.5), PtXy(1.5, 1.5), PtXy(0.5, 1.5))
+	right := PolygonPt(PtXy(2.5, 2.5), PtXy(3.5, 2.5), PtXy(3.5, 3.5), PtXy(2.5, 3.5))
+
+	checkTriangles := func(name string, h int, pts []Pt, tris [][3]int, count int, area Length) {
+		if len(tris) != count {
+			t.Errorf("[%d]%s() (count) failed. %d != %d. %v",
+				h, name, len(tris), count, tris)
+		}
+		var sum Length
+		for _, poly := range TrianglesFromIndexes(pts, tris) {
+			if w := poly.Winding(); w != WINDING_COUNTERCLOCKWISE {
+				t.Errorf("[%d]%s() (winding) failed. %v != %v. %v",
+					h, name, w, WINDING_COUNTERCLOCKWISE, poly)
+			}
+			sum += poly.Area()
+		}
+		if !IsEqual(sum, area) {
+			t.Errorf("[%d]%s() (area) failed. %f != %f. %v",
+				h, name, sum, area, tris)
+		}
+	}
+
+	polygonTests := []struct {
+		poly  Polygon
+		count int
+		area  Length
+	}{
+		{
+			//0
+			square, 2, 16,
+		}, {
+			square.Reverse(), 2, 16,
+		}, {
+			ell, 4, 7,
+		}, {
+			comb, 10, 11,
+		}, {
+			circle, 22, circle.Area(),
+		}, {
+			//5
+			PolygonPt(PtXy(0, 0), PtXy(1, 1), PtXy(2, 2)), 0, 0,
+		},
+	}
+	for h, test := range polygonTests {
+		poly := test.poly
+		checkTriangles("TriangulatePolygon", h, poly.Points(), TriangulatePolygon(poly), test.count, test.area)
+		checkTriangles("TriangulateDelaunayPolygon", h, poly.Points(), TriangulateDelaunayPolygon(poly), test.count, test.area)
+	}
+
+	regionTests := []struct {
+		r     Region
+		count int
+		area  Length
+	}{
+		{
+			//0
+			RegionPolygon(square), 2, 16,
+		}, {
+			RegionPolygon(square, inner), 8, 12,
+		}, {
+			RegionPolygon(square, left, right), 14, 14,
+		}, {
+			RegionPolygon(circle, inner), 28, circle.Area() - 4,
+		},
+	}
+	for h, test := range regionTests {
+		r := test.r
+		checkTriangles("TriangulateRegion", h, r.Points(), TriangulateRegion(r), test.count, test.area)
+		checkTriangles("TriangulateDelaunayRegion", h, r.Points(), TriangulateDelaunayRegion(r), test.count, test.area)
+	}
+
+	// The Delaunay triangles of a convex polygon have no points inside their
+	// circumcircles.
+	pts := circle.Points()
+	for _, tri := range TriangulateDelaunayPolygon(circle) {
+		for h, p := range pts {
+			if incirclePts(pts[tri[0]], pts[tri[1]], pts[tri[2]], p) > 0 {
+				t.Errorf("[%d]TriangulateDelaunayPolygon(%v) failed. %v inside %v",
+					h, circle, p, tri)
+			}
+		}
+	}
+}